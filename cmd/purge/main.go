@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gin-mania-backend/internal/config"
+	"gin-mania-backend/internal/search"
+	"gin-mania-backend/pkg/database"
+	"gin-mania-backend/pkg/logging"
+)
+
+// defaultRetention keeps soft-deleted gins restorable for 30 days.
+const defaultRetention = 30 * 24 * time.Hour
+
+func main() {
+	retention := flag.Duration("retention", defaultRetention, "permanently remove gins soft-deleted longer ago than this")
+	flag.Parse()
+
+	if err := run(context.Background(), *retention); err != nil {
+		fmt.Fprintf(os.Stderr, "fatal: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, retention time.Duration) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	logger, err := logging.NewLogger(cfg.Logging)
+	if err != nil {
+		return fmt.Errorf("initialize logger: %w", err)
+	}
+	defer func() { _ = logger.Sync() }()
+
+	db, err := database.OpenPostgres(ctx, database.Config{
+		DSN:             cfg.Database.DSN,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+	})
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("retrieve sql DB: %w", err)
+	}
+	defer sqlDB.Close()
+
+	searchService := search.NewService(search.NewRepository(db))
+
+	purged, err := searchService.Purge(ctx, retention)
+	if err != nil {
+		return fmt.Errorf("purge deleted gins: %w", err)
+	}
+
+	logger.Info("purged soft-deleted gins",
+		zap.Int64("count", purged),
+		zap.Duration("retention", retention),
+	)
+	return nil
+}
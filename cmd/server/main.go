@@ -7,14 +7,23 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"go.uber.org/zap"
 
+	"gin-mania-backend/internal/apikey"
+	"gin-mania-backend/internal/auth"
 	"gin-mania-backend/internal/config"
+	"gin-mania-backend/internal/distillery"
 	httpRouter "gin-mania-backend/internal/http/router"
+	"gin-mania-backend/internal/importer"
+	"gin-mania-backend/internal/jobs"
+	"gin-mania-backend/internal/moderation"
 	"gin-mania-backend/internal/search"
+	"gin-mania-backend/internal/tasting"
+	"gin-mania-backend/internal/user"
 	"gin-mania-backend/pkg/database"
 	"gin-mania-backend/pkg/logging"
 )
@@ -56,9 +65,51 @@ func run(ctx context.Context) error {
 	defer sqlDB.Close()
 
 	searchService := search.NewService(search.NewRepository(db))
+	distilleryService := distillery.NewService(distillery.NewRepository(db))
+	jobRepository := jobs.NewRepository(db)
+	jobService := jobs.NewService(jobRepository)
+	tastingService := tasting.NewService(tasting.NewRepository(db))
+	moderationService := moderation.NewService(moderation.NewRepository(db))
+	userService := user.NewService(user.NewRepository(db))
+	apiKeyService := apikey.NewService(apikey.NewRepository(db))
+	importService := importer.NewService(importer.NewRepository(db), searchService, jobService)
+
+	runner := jobs.NewRunner(jobRepository, logger, jobs.RunnerConfig{
+		Workers:       cfg.Jobs.Workers,
+		PollInterval:  cfg.Jobs.PollInterval,
+		LeaseDuration: cfg.Jobs.LeaseDuration,
+	})
+	runner.Register(importer.JobKind, importService.Process)
+	runner.OnFailure(importer.JobKind, importService.Abandon)
+
+	var verifier *auth.Verifier
+	if cfg.Auth.Enabled {
+		verifier, err = auth.NewVerifier(auth.Config{
+			Domain:   cfg.Auth.Domain,
+			Audience: cfg.Auth.Audience,
+			JWKSURL:  cfg.Auth.JWKSURL,
+		})
+		if err != nil {
+			return fmt.Errorf("initialize auth: %w", err)
+		}
+	} else if strings.EqualFold(cfg.App.Environment, "development") {
+		logger.Warn("authentication is disabled; all requests are anonymous and role checks are skipped")
+	} else {
+		logger.Error("AUTHENTICATION IS DISABLED by AUTH_DISABLED=true outside development: every /admin route is open to anonymous callers",
+			zap.String("environment", cfg.App.Environment),
+		)
+	}
 
 	engine, err := httpRouter.New(cfg, logger, httpRouter.Dependencies{
-		SearchService: searchService,
+		SearchService:     searchService,
+		DistilleryService: distilleryService,
+		ImportService:     importService,
+		JobService:        jobService,
+		TastingService:    tastingService,
+		ModerationService: moderationService,
+		UserService:       userService,
+		APIKeyService:     apiKeyService,
+		Verifier:          verifier,
 	})
 	if err != nil {
 		return fmt.Errorf("initialize router: %w", err)
@@ -76,6 +127,20 @@ func run(ctx context.Context) error {
 		zap.String("environment", cfg.App.Environment),
 	)
 
+	// Jobs interrupted by shutdown are released back to the queue and resumed by the next
+	// process, so the runner is stopped only once the server has finished handling requests.
+	runnerCtx, stopRunner := context.WithCancel(ctx)
+	runnerDone := make(chan struct{})
+	go func() {
+		defer close(runnerDone)
+		runner.Run(runnerCtx)
+	}()
+	defer func() {
+		stopRunner()
+		<-runnerDone
+		logger.Info("job runner stopped")
+	}()
+
 	if err := startServer(ctx, server, logger, cfg.Server.ShutdownTimeout); err != nil {
 		return err
 	}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_gin_public_id;
+ALTER TABLE gin DROP COLUMN IF EXISTS public_id;
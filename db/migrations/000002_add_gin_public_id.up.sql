@@ -0,0 +1,3 @@
+ALTER TABLE gin ADD COLUMN IF NOT EXISTS public_id UUID NOT NULL DEFAULT gen_random_uuid();
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gin_public_id ON gin (public_id);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_gin_set_updated_at ON gin;
+DROP FUNCTION IF EXISTS set_updated_at();
@@ -0,0 +1,12 @@
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_gin_set_updated_at ON gin;
+CREATE TRIGGER trg_gin_set_updated_at
+    BEFORE UPDATE ON gin
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
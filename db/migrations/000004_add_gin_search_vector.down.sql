@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_gin_search_vector;
+ALTER TABLE gin DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS gin_botanicals_text(TEXT[]);
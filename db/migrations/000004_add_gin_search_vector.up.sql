@@ -0,0 +1,14 @@
+-- array_to_string is only STABLE, so wrap it to satisfy the immutability requirement of
+-- generated columns. The wrapper is safe because it is only ever applied to text[].
+CREATE OR REPLACE FUNCTION gin_botanicals_text(botanicals TEXT[]) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT COALESCE(array_to_string(botanicals, ' '), '') $$;
+
+ALTER TABLE gin ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('english', gin_botanicals_text(botanicals)), 'B') ||
+    setweight(to_tsvector('english', COALESCE(country, '')), 'C') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'D')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_gin_search_vector ON gin USING GIN (search_vector);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_gin_name_trgm;
@@ -0,0 +1,3 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_gin_name_trgm ON gin USING GIN (LOWER(name) gin_trgm_ops);
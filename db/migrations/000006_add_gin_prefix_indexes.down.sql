@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_gin_country_prefix;
+DROP INDEX IF EXISTS idx_gin_name_prefix;
@@ -0,0 +1,3 @@
+-- text_pattern_ops lets btree indexes serve LIKE 'prefix%' lookups regardless of collation.
+CREATE INDEX IF NOT EXISTS idx_gin_name_prefix ON gin (LOWER(name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_gin_country_prefix ON gin (LOWER(country) text_pattern_ops);
@@ -0,0 +1,37 @@
+DROP TRIGGER IF EXISTS trg_botanicals_touch_gins ON botanicals;
+DROP FUNCTION IF EXISTS botanicals_touch_gins();
+DROP TRIGGER IF EXISTS trg_gin_botanicals_touch_gin ON gin_botanicals;
+DROP FUNCTION IF EXISTS gin_botanicals_touch_gin();
+DROP TRIGGER IF EXISTS trg_gin_search_vector ON gin;
+DROP FUNCTION IF EXISTS gin_refresh_search_vector();
+
+ALTER TABLE gin ADD COLUMN IF NOT EXISTS botanicals TEXT[] DEFAULT ARRAY[]::TEXT[];
+
+ALTER TABLE gin DISABLE TRIGGER trg_gin_set_updated_at;
+UPDATE gin AS g
+SET botanicals = COALESCE((
+    SELECT array_agg(b.name ORDER BY gb.position, b.name)
+    FROM gin_botanicals AS gb
+    JOIN botanicals AS b ON b.id = gb.botanical_id
+    WHERE gb.gin_id = g.id
+), ARRAY[]::TEXT[]);
+ALTER TABLE gin ENABLE TRIGGER trg_gin_set_updated_at;
+
+DROP INDEX IF EXISTS idx_gin_search_vector;
+ALTER TABLE gin DROP COLUMN IF EXISTS search_vector;
+
+CREATE OR REPLACE FUNCTION gin_botanicals_text(botanicals TEXT[]) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT COALESCE(array_to_string(botanicals, ' '), '') $$;
+
+ALTER TABLE gin ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+    setweight(to_tsvector('english', gin_botanicals_text(botanicals)), 'B') ||
+    setweight(to_tsvector('english', COALESCE(country, '')), 'C') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'D')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_gin_search_vector ON gin USING GIN (search_vector);
+
+DROP TABLE IF EXISTS gin_botanicals;
+DROP TABLE IF EXISTS botanicals;
@@ -0,0 +1,110 @@
+CREATE TABLE IF NOT EXISTS botanicals (
+    id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_botanicals_public_id ON botanicals (public_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_botanicals_name ON botanicals (LOWER(name));
+CREATE INDEX IF NOT EXISTS idx_botanicals_name_prefix ON botanicals (LOWER(name) text_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS gin_botanicals (
+    gin_id BIGINT NOT NULL REFERENCES gin (id) ON DELETE CASCADE,
+    botanical_id BIGINT NOT NULL REFERENCES botanicals (id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (gin_id, botanical_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gin_botanicals_botanical_id ON gin_botanicals (botanical_id);
+
+-- Collapse case and whitespace variants ("Juniper", " juniper ") into one master row,
+-- keeping the first spelling in sort order as the display name.
+INSERT INTO botanicals (name)
+SELECT DISTINCT ON (LOWER(cleaned)) cleaned
+FROM (
+    SELECT regexp_replace(TRIM(botanical), '\s+', ' ', 'g') AS cleaned
+    FROM gin, unnest(botanicals) AS botanical
+) AS source
+WHERE cleaned <> ''
+ORDER BY LOWER(cleaned), cleaned
+ON CONFLICT DO NOTHING;
+
+INSERT INTO gin_botanicals (gin_id, botanical_id, position)
+SELECT g.id, b.id, MIN(source.ordinality) - 1
+FROM gin AS g
+CROSS JOIN LATERAL unnest(g.botanicals) WITH ORDINALITY AS source (botanical, ordinality)
+JOIN botanicals AS b ON LOWER(b.name) = LOWER(regexp_replace(TRIM(source.botanical), '\s+', ' ', 'g'))
+GROUP BY g.id, b.id
+ON CONFLICT DO NOTHING;
+
+-- The search vector now depends on gin_botanicals, which a generated column cannot reference,
+-- so it becomes a regular column maintained by triggers.
+DROP INDEX IF EXISTS idx_gin_search_vector;
+ALTER TABLE gin DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE gin DROP COLUMN IF EXISTS botanicals;
+DROP FUNCTION IF EXISTS gin_botanicals_text(TEXT[]);
+ALTER TABLE gin ADD COLUMN search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION gin_refresh_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(b.name, ' ')
+            FROM gin_botanicals AS gb
+            JOIN botanicals AS b ON b.id = gb.botanical_id
+            WHERE gb.gin_id = NEW.id
+        ), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.country, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_gin_search_vector ON gin;
+CREATE TRIGGER trg_gin_search_vector
+    BEFORE INSERT OR UPDATE ON gin
+    FOR EACH ROW
+    EXECUTE FUNCTION gin_refresh_search_vector();
+
+-- Touching the parent gin row re-runs trg_gin_search_vector and bumps updated_at.
+CREATE OR REPLACE FUNCTION gin_botanicals_touch_gin() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE gin SET updated_at = NOW() WHERE id = OLD.gin_id;
+    ELSE
+        UPDATE gin SET updated_at = NOW() WHERE id = NEW.gin_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_gin_botanicals_touch_gin ON gin_botanicals;
+CREATE TRIGGER trg_gin_botanicals_touch_gin
+    AFTER INSERT OR UPDATE OR DELETE ON gin_botanicals
+    FOR EACH ROW
+    EXECUTE FUNCTION gin_botanicals_touch_gin();
+
+CREATE OR REPLACE FUNCTION botanicals_touch_gins() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.name IS DISTINCT FROM OLD.name THEN
+        UPDATE gin SET updated_at = NOW()
+        WHERE id IN (SELECT gin_id FROM gin_botanicals WHERE botanical_id = NEW.id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_botanicals_touch_gins ON botanicals;
+CREATE TRIGGER trg_botanicals_touch_gins
+    AFTER UPDATE ON botanicals
+    FOR EACH ROW
+    EXECUTE FUNCTION botanicals_touch_gins();
+
+-- Backfill vectors without disturbing existing updated_at values.
+ALTER TABLE gin DISABLE TRIGGER trg_gin_set_updated_at;
+UPDATE gin SET search_vector = NULL;
+ALTER TABLE gin ENABLE TRIGGER trg_gin_set_updated_at;
+
+CREATE INDEX IF NOT EXISTS idx_gin_search_vector ON gin USING GIN (search_vector);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS gin_flavor_tags;
+DROP TABLE IF EXISTS flavor_tags;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS flavor_tags (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_flavor_tags_code ON flavor_tags (code);
+
+CREATE TABLE IF NOT EXISTS gin_flavor_tags (
+    gin_id BIGINT NOT NULL REFERENCES gin (id) ON DELETE CASCADE,
+    flavor_tag_id BIGINT NOT NULL REFERENCES flavor_tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (gin_id, flavor_tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gin_flavor_tags_flavor_tag_id ON gin_flavor_tags (flavor_tag_id, gin_id);
+
+INSERT INTO flavor_tags (code, label) VALUES
+    ('juniper', 'Juniper-forward'),
+    ('citrus', 'Citrus'),
+    ('floral', 'Floral'),
+    ('herbal', 'Herbal'),
+    ('spice', 'Spice'),
+    ('fruity', 'Fruity'),
+    ('earthy', 'Earthy'),
+    ('savory', 'Savory'),
+    ('sweet', 'Sweet')
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,30 @@
+CREATE OR REPLACE FUNCTION gin_refresh_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(b.name, ' ')
+            FROM gin_botanicals AS gb
+            JOIN botanicals AS b ON b.id = gb.botanical_id
+            WHERE gb.gin_id = NEW.id
+        ), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.country, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_gin_abv;
+DROP INDEX IF EXISTS idx_gin_region;
+DROP INDEX IF EXISTS idx_gin_distillery;
+
+ALTER TABLE gin
+    DROP COLUMN IF EXISTS tasting_notes,
+    DROP COLUMN IF EXISTS image_url,
+    DROP COLUMN IF EXISTS abv,
+    DROP COLUMN IF EXISTS region,
+    DROP COLUMN IF EXISTS distillery;
+
+ALTER TABLE gin DISABLE TRIGGER trg_gin_set_updated_at;
+UPDATE gin SET search_vector = NULL;
+ALTER TABLE gin ENABLE TRIGGER trg_gin_set_updated_at;
@@ -0,0 +1,31 @@
+ALTER TABLE gin
+    ADD COLUMN IF NOT EXISTS distillery VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS region VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS abv NUMERIC(4, 1) CHECK (abv >= 0 AND abv <= 100),
+    ADD COLUMN IF NOT EXISTS image_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tasting_notes TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_gin_distillery ON gin (LOWER(distillery));
+CREATE INDEX IF NOT EXISTS idx_gin_region ON gin (LOWER(region));
+CREATE INDEX IF NOT EXISTS idx_gin_abv ON gin (abv);
+
+CREATE OR REPLACE FUNCTION gin_refresh_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(b.name, ' ')
+            FROM gin_botanicals AS gb
+            JOIN botanicals AS b ON b.id = gb.botanical_id
+            WHERE gb.gin_id = NEW.id
+        ), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.distillery, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.country, '') || ' ' || COALESCE(NEW.region, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '') || ' ' || COALESCE(NEW.tasting_notes, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE gin DISABLE TRIGGER trg_gin_set_updated_at;
+UPDATE gin SET search_vector = NULL;
+ALTER TABLE gin ENABLE TRIGGER trg_gin_set_updated_at;
@@ -0,0 +1,38 @@
+DROP TRIGGER IF EXISTS trg_distilleries_touch_gins ON distilleries;
+DROP FUNCTION IF EXISTS distilleries_touch_gins();
+
+ALTER TABLE gin ADD COLUMN IF NOT EXISTS distillery VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_gin_distillery ON gin (LOWER(distillery));
+
+ALTER TABLE gin DISABLE TRIGGER trg_gin_set_updated_at;
+UPDATE gin AS g
+SET distillery = d.name
+FROM distilleries AS d
+WHERE d.id = g.distillery_id;
+ALTER TABLE gin ENABLE TRIGGER trg_gin_set_updated_at;
+
+DROP INDEX IF EXISTS idx_gin_distillery_id;
+ALTER TABLE gin DROP COLUMN IF EXISTS distillery_id;
+
+CREATE OR REPLACE FUNCTION gin_refresh_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(b.name, ' ')
+            FROM gin_botanicals AS gb
+            JOIN botanicals AS b ON b.id = gb.botanical_id
+            WHERE gb.gin_id = NEW.id
+        ), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.distillery, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.country, '') || ' ' || COALESCE(NEW.region, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '') || ' ' || COALESCE(NEW.tasting_notes, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE gin DISABLE TRIGGER trg_gin_set_updated_at;
+UPDATE gin SET search_vector = NULL;
+ALTER TABLE gin ENABLE TRIGGER trg_gin_set_updated_at;
+
+DROP TABLE IF EXISTS distilleries;
@@ -0,0 +1,89 @@
+CREATE TABLE IF NOT EXISTS distilleries (
+    id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    country VARCHAR(255) NOT NULL DEFAULT '',
+    region VARCHAR(255) NOT NULL DEFAULT '',
+    founded_year SMALLINT,
+    website TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_distilleries_public_id ON distilleries (public_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_distilleries_name ON distilleries (LOWER(name));
+
+DROP TRIGGER IF EXISTS trg_distilleries_set_updated_at ON distilleries;
+CREATE TRIGGER trg_distilleries_set_updated_at
+    BEFORE UPDATE ON distilleries
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- Promote the free-text distillery names on gins into distillery rows, taking country and
+-- region from the first gin listed for each distillery.
+INSERT INTO distilleries (name, country, region)
+SELECT DISTINCT ON (LOWER(TRIM(distillery))) TRIM(distillery), country, region
+FROM gin
+WHERE TRIM(distillery) <> ''
+ORDER BY LOWER(TRIM(distillery)), id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE gin ADD COLUMN IF NOT EXISTS distillery_id BIGINT REFERENCES distilleries (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_gin_distillery_id ON gin (distillery_id);
+
+ALTER TABLE gin DISABLE TRIGGER trg_gin_set_updated_at;
+UPDATE gin AS g
+SET distillery_id = d.id
+FROM distilleries AS d
+WHERE LOWER(d.name) = LOWER(TRIM(g.distillery));
+ALTER TABLE gin ENABLE TRIGGER trg_gin_set_updated_at;
+
+-- Refuse to drop the free-text column while any gin still has a name that was not linked.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM gin WHERE TRIM(distillery) <> '' AND distillery_id IS NULL) THEN
+        RAISE EXCEPTION 'gin.distillery values were not migrated to distilleries';
+    END IF;
+END;
+$$;
+
+DROP INDEX IF EXISTS idx_gin_distillery;
+ALTER TABLE gin DROP COLUMN IF EXISTS distillery;
+
+CREATE OR REPLACE FUNCTION gin_refresh_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE((
+            SELECT string_agg(b.name, ' ')
+            FROM gin_botanicals AS gb
+            JOIN botanicals AS b ON b.id = gb.botanical_id
+            WHERE gb.gin_id = NEW.id
+        ), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE((
+            SELECT d.name FROM distilleries AS d WHERE d.id = NEW.distillery_id
+        ), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.country, '') || ' ' || COALESCE(NEW.region, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '') || ' ' || COALESCE(NEW.tasting_notes, '')), 'D');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION distilleries_touch_gins() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.name IS DISTINCT FROM OLD.name THEN
+        UPDATE gin SET updated_at = NOW() WHERE distillery_id = NEW.id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_distilleries_touch_gins ON distilleries;
+CREATE TRIGGER trg_distilleries_touch_gins
+    AFTER UPDATE ON distilleries
+    FOR EACH ROW
+    EXECUTE FUNCTION distilleries_touch_gins();
+
+ALTER TABLE gin DISABLE TRIGGER trg_gin_set_updated_at;
+UPDATE gin SET search_vector = NULL;
+ALTER TABLE gin ENABLE TRIGGER trg_gin_set_updated_at;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_gin_status;
+ALTER TABLE gin DROP CONSTRAINT IF EXISTS chk_gin_status;
+ALTER TABLE gin DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+-- Existing rows were already publicly visible, so they start out published; new rows
+-- default to draft until an administrator publishes them.
+ALTER TABLE gin ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published';
+ALTER TABLE gin ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE gin ADD CONSTRAINT chk_gin_status CHECK (status IN ('draft', 'published', 'archived'));
+
+CREATE INDEX IF NOT EXISTS idx_gin_status ON gin (status);
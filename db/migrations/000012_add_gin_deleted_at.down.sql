@@ -0,0 +1,12 @@
+-- Soft-deleted rows would become visible again, so refuse to drop the column while any
+-- exist. Restore or purge them first.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM gin WHERE deleted_at IS NOT NULL) THEN
+        RAISE EXCEPTION 'gin has soft-deleted rows; restore or purge them before dropping deleted_at';
+    END IF;
+END;
+$$;
+
+DROP INDEX IF EXISTS idx_gin_deleted_at;
+ALTER TABLE gin DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE gin ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_gin_deleted_at ON gin (deleted_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS gin_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    gin_id BIGINT NOT NULL REFERENCES gin (id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    snapshot JSONB NOT NULL,
+    diff JSONB NOT NULL DEFAULT '{}'::jsonb,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gin_revisions_public_id ON gin_revisions (public_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gin_revisions_gin_revision ON gin_revisions (gin_id, revision);
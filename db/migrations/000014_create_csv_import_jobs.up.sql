@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS csv_import_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    uploaded_by VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'queued',
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    succeeded_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    summary JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_csv_import_jobs_status CHECK (status IN ('queued', 'processing', 'completed', 'failed'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_csv_import_jobs_public_id ON csv_import_jobs (public_id);
+CREATE INDEX IF NOT EXISTS idx_csv_import_jobs_created_at ON csv_import_jobs (created_at DESC);
+
+DROP TRIGGER IF EXISTS trg_csv_import_jobs_set_updated_at ON csv_import_jobs;
+CREATE TRIGGER trg_csv_import_jobs_set_updated_at
+    BEFORE UPDATE ON csv_import_jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
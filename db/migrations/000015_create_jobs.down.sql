@@ -0,0 +1,3 @@
+ALTER TABLE csv_import_jobs DROP COLUMN IF EXISTS content;
+
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS jobs (
+    id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    kind VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'queued',
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    result JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    progress_done INTEGER NOT NULL DEFAULT 0,
+    progress_total INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 3,
+    run_after TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_by VARCHAR(255) NOT NULL DEFAULT '',
+    locked_until TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_jobs_status CHECK (status IN ('queued', 'running', 'completed', 'failed'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_public_id ON jobs (public_id);
+-- Workers only scan jobs that can still be claimed: queued ones and running ones whose lease may have expired.
+CREATE INDEX IF NOT EXISTS idx_jobs_claimable ON jobs (run_after, id) WHERE status IN ('queued', 'running');
+
+DROP TRIGGER IF EXISTS trg_jobs_set_updated_at ON jobs;
+CREATE TRIGGER trg_jobs_set_updated_at
+    BEFORE UPDATE ON jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
+
+-- Uploaded files are kept until their import finishes so that interrupted imports can be resumed.
+ALTER TABLE csv_import_jobs ADD COLUMN IF NOT EXISTS content BYTEA;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tasting_logs;
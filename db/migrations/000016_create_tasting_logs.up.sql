@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS tasting_logs (
+    id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    gin_id BIGINT NOT NULL REFERENCES gin (id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL,
+    memo TEXT NOT NULL DEFAULT '',
+    tasted_on DATE NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_tasting_logs_rating CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT chk_tasting_logs_status CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasting_logs_public_id ON tasting_logs (public_id);
+CREATE INDEX IF NOT EXISTS idx_tasting_logs_user_tasted_on ON tasting_logs (user_id, tasted_on DESC, id DESC);
+-- Supports the per-gin rating aggregates computed for search and detail responses.
+CREATE INDEX IF NOT EXISTS idx_tasting_logs_gin_status ON tasting_logs (gin_id, status) INCLUDE (rating);
+
+DROP TRIGGER IF EXISTS trg_tasting_logs_set_updated_at ON tasting_logs;
+CREATE TRIGGER trg_tasting_logs_set_updated_at
+    BEFORE UPDATE ON tasting_logs
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
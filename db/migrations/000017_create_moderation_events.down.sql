@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasting_logs_status_created_at;
+DROP TABLE IF EXISTS moderation_events;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS moderation_events (
+    id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    tasting_id BIGINT NOT NULL REFERENCES tasting_logs (id) ON DELETE CASCADE,
+    action VARCHAR(16) NOT NULL,
+    from_status VARCHAR(16) NOT NULL,
+    to_status VARCHAR(16) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_moderation_events_action CHECK (action IN ('approve', 'reject'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_events_public_id ON moderation_events (public_id);
+CREATE INDEX IF NOT EXISTS idx_moderation_events_tasting_id ON moderation_events (tasting_id, created_at DESC);
+-- Serves the moderation queue, which lists tastings of one status oldest first.
+CREATE INDEX IF NOT EXISTS idx_tasting_logs_status_created_at ON tasting_logs (status, created_at, id);
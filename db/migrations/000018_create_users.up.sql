@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    public_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    auth_subject VARCHAR(255) NOT NULL,
+    email VARCHAR(320) NOT NULL DEFAULT '',
+    display_name VARCHAR(255) NOT NULL DEFAULT '',
+    role VARCHAR(16) NOT NULL DEFAULT 'guest',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_users_role CHECK (role IN ('admin', 'member', 'guest'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_public_id ON users (public_id);
+-- Provisioning upserts on the subject, so concurrent first requests resolve to one row.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_auth_subject ON users (auth_subject);
+
+DROP TRIGGER IF EXISTS trg_users_set_updated_at ON users;
+CREATE TRIGGER trg_users_set_updated_at
+    BEFORE UPDATE ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION set_updated_at();
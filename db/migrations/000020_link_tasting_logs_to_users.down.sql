@@ -0,0 +1 @@
+ALTER TABLE tasting_logs DROP CONSTRAINT IF EXISTS fk_tasting_logs_user;
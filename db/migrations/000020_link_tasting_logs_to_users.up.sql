@@ -0,0 +1,9 @@
+-- Tastings recorded before users were provisioned refer to subjects without a users row.
+-- Create those users as guests; their role is synced from the token on their next request.
+INSERT INTO users (auth_subject)
+SELECT DISTINCT user_id FROM tasting_logs
+ON CONFLICT (auth_subject) DO NOTHING;
+
+ALTER TABLE tasting_logs
+    ADD CONSTRAINT fk_tasting_logs_user
+    FOREIGN KEY (user_id) REFERENCES users (auth_subject) ON UPDATE CASCADE ON DELETE CASCADE;
@@ -0,0 +1,22 @@
+-- Revisions of purged gins have no gin row to cascade from, so refuse to drop their only link.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM gin_revisions WHERE gin_id IS NULL) THEN
+        RAISE EXCEPTION 'gin_revisions holds the history of purged gins';
+    END IF;
+END;
+$$;
+
+ALTER TABLE tasting_logs DROP CONSTRAINT IF EXISTS tasting_logs_gin_id_fkey;
+ALTER TABLE tasting_logs
+    ADD CONSTRAINT tasting_logs_gin_id_fkey
+    FOREIGN KEY (gin_id) REFERENCES gin (id) ON DELETE CASCADE;
+
+ALTER TABLE gin_revisions DROP CONSTRAINT IF EXISTS gin_revisions_gin_id_fkey;
+ALTER TABLE gin_revisions
+    ADD CONSTRAINT gin_revisions_gin_id_fkey
+    FOREIGN KEY (gin_id) REFERENCES gin (id) ON DELETE CASCADE;
+ALTER TABLE gin_revisions ALTER COLUMN gin_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_gin_revisions_gin_public_id_revision;
+ALTER TABLE gin_revisions DROP COLUMN IF EXISTS gin_public_id;
@@ -0,0 +1,23 @@
+-- Purging a gin must not take its audit trail or its tastings with it. Revisions keep the
+-- public id of their gin and outlive the gin row as its tombstone, while gins that still
+-- have tastings cannot be removed at all, which also keeps their moderation history.
+ALTER TABLE gin_revisions ADD COLUMN IF NOT EXISTS gin_public_id UUID;
+
+UPDATE gin_revisions AS r
+SET gin_public_id = g.public_id
+FROM gin AS g
+WHERE g.id = r.gin_id AND r.gin_public_id IS NULL;
+
+ALTER TABLE gin_revisions ALTER COLUMN gin_public_id SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gin_revisions_gin_public_id_revision ON gin_revisions (gin_public_id, revision);
+
+ALTER TABLE gin_revisions ALTER COLUMN gin_id DROP NOT NULL;
+ALTER TABLE gin_revisions DROP CONSTRAINT IF EXISTS gin_revisions_gin_id_fkey;
+ALTER TABLE gin_revisions
+    ADD CONSTRAINT gin_revisions_gin_id_fkey
+    FOREIGN KEY (gin_id) REFERENCES gin (id) ON DELETE SET NULL;
+
+ALTER TABLE tasting_logs DROP CONSTRAINT IF EXISTS tasting_logs_gin_id_fkey;
+ALTER TABLE tasting_logs
+    ADD CONSTRAINT tasting_logs_gin_id_fkey
+    FOREIGN KEY (gin_id) REFERENCES gin (id) ON DELETE RESTRICT;
@@ -0,0 +1,66 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Scope is a permission granted to an API key.
+type Scope string
+
+const (
+	// ScopeCatalogueRead allows reading the gin catalogue.
+	ScopeCatalogueRead Scope = "catalogue:read"
+	// ScopeCatalogueWrite allows managing gins and distilleries, including imports.
+	ScopeCatalogueWrite Scope = "catalogue:write"
+)
+
+// AllScopes lists every scope that can be granted.
+var AllScopes = []Scope{ScopeCatalogueRead, ScopeCatalogueWrite}
+
+// APIKey is a credential for machine-to-machine access. Only a hash of the secret is stored;
+// the key itself is shown once, when it is issued.
+type APIKey struct {
+	ID         uint           `json:"-" gorm:"column:id;primaryKey"`
+	PublicID   uuid.UUID      `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	Name       string         `json:"name" gorm:"column:name;type:varchar(255);not null"`
+	Prefix     string         `json:"prefix" gorm:"column:prefix;type:varchar(32);not null"`
+	KeyHash    []byte         `json:"-" gorm:"column:key_hash;type:bytea;not null"`
+	Scopes     pq.StringArray `json:"scopes" gorm:"column:scopes;type:text[];not null"`
+	CreatedBy  string         `json:"created_by" gorm:"column:created_by;type:varchar(255);not null"`
+	ExpiresAt  *time.Time     `json:"expires_at" gorm:"column:expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at" gorm:"column:last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at" gorm:"column:revoked_at"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time      `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// TableName specifies the PostgreSQL table name for API keys.
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// HasScope reports whether the key grants scope.
+func (k *APIKey) HasScope(scope Scope) bool {
+	for _, candidate := range k.Scopes {
+		if Scope(candidate) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IssuedKey is a newly issued key together with its secret, which cannot be retrieved later.
+type IssuedKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// Input carries the attributes of a new API key.
+type Input struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
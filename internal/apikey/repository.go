@@ -0,0 +1,82 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gin-mania-backend/pkg/database"
+)
+
+// Repository defines access methods to API key storage.
+type Repository interface {
+	Create(ctx context.Context, key *APIKey) error
+	List(ctx context.Context) ([]APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	Touch(ctx context.Context, key *APIKey, interval time.Duration) error
+}
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+// NewRepository constructs a Repository backed by GORM.
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) Create(ctx context.Context, key *APIKey) error {
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		if database.IsUniqueViolation(err) {
+			return errDuplicatePrefix
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormRepository) List(ctx context.Context) ([]APIKey, error) {
+	var keys []APIKey
+	err := r.db.WithContext(ctx).Order("created_at DESC, id DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *gormRepository) GetByPrefix(ctx context.Context, prefix string) (*APIKey, error) {
+	var key APIKey
+
+	err := r.db.WithContext(ctx).Where("prefix = ?", prefix).Take(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// Revoke marks a key revoked. Revoking a key again keeps its original revocation time.
+func (r *gormRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&APIKey{}).
+		Where("public_id = ?", id).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, NOW())"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Touch records that the key was used. Writes are skipped while the recorded time is more
+// recent than interval, so a busy key does not update its row on every request.
+func (r *gormRepository) Touch(ctx context.Context, key *APIKey, interval time.Duration) error {
+	return r.db.WithContext(ctx).Model(&APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", key.ID, time.Now().Add(-interval)).
+		Update("last_used_at", gorm.Expr("NOW()")).Error
+}
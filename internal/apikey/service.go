@@ -0,0 +1,227 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gin-mania-backend/pkg/requestctx"
+)
+
+const (
+	// keyPrefix marks Gin Mania API keys so that leaked keys are easy to recognise.
+	keyPrefix = "gmk"
+	// prefixBytes and secretBytes size the random lookup prefix and secret of a key.
+	prefixBytes = 6
+	secretBytes = 32
+	// touchInterval is how stale last_used_at may get before a request updates it.
+	touchInterval = time.Minute
+	// issueAttempts retries issuing when the random prefix collides with an existing key.
+	issueAttempts    = 3
+	maxVarcharLength = 255
+)
+
+var (
+	// ErrRepositoryNotConfigured indicates that the service was constructed without a backing repository.
+	ErrRepositoryNotConfigured = errors.New("api key repository not configured")
+	// ErrNotFound is returned when the requested API key does not exist.
+	ErrNotFound = errors.New("api key not found")
+	// ErrInvalidAPIKey is returned when an issue request fails validation.
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	// ErrUnauthorized is returned when a presented key is unknown, revoked or expired.
+	ErrUnauthorized = errors.New("invalid or expired api key")
+
+	errDuplicatePrefix = errors.New("api key prefix already exists")
+)
+
+// Service issues, authenticates and revokes API keys.
+type Service struct {
+	repo Repository
+}
+
+// NewService constructs a new Service using the provided repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Issue creates a key with the given scopes and returns it with its secret. The secret is
+// not stored and cannot be shown again.
+func (s *Service) Issue(ctx context.Context, input Input) (*IssuedKey, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	key := &APIKey{
+		Name:      strings.TrimSpace(input.Name),
+		Scopes:    normalizeScopes(input.Scopes),
+		CreatedBy: requestctx.Actor(ctx),
+		ExpiresAt: input.ExpiresAt,
+	}
+	if err := validate(key); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		prefix, secret, err := generate()
+		if err != nil {
+			return nil, err
+		}
+
+		key.PublicID = uuid.New()
+		key.Prefix = prefix
+		key.KeyHash = hash(secret)
+		err = s.repo.Create(ctx, key)
+		if errors.Is(err, errDuplicatePrefix) && attempt < issueAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &IssuedKey{APIKey: key, Key: format(prefix, secret)}, nil
+	}
+}
+
+// List returns every key, newest first, including revoked and expired ones.
+func (s *Service) List(ctx context.Context) ([]APIKey, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	return s.repo.List(ctx)
+}
+
+// Revoke disables a key immediately.
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID) error {
+	if s.repo == nil {
+		return ErrRepositoryNotConfigured
+	}
+
+	return s.repo.Revoke(ctx, id)
+}
+
+// Authenticate returns the active key matching raw and records that it was used.
+func (s *Service) Authenticate(ctx context.Context, raw string) (*APIKey, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	prefix, secret, ok := parse(raw)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	key, err := s.repo.GetByPrefix(ctx, prefix)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(key.KeyHash, hash(secret)) != 1 {
+		return nil, ErrUnauthorized
+	}
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && !time.Now().Before(*key.ExpiresAt)) {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.repo.Touch(ctx, key, touchInterval); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func validate(key *APIKey) error {
+	var problems []string
+
+	if key.Name == "" {
+		problems = append(problems, "name is required")
+	} else if utf8.RuneCountInString(key.Name) > maxVarcharLength {
+		problems = append(problems, fmt.Sprintf("name must be at most %d characters", maxVarcharLength))
+	}
+
+	if len(key.Scopes) == 0 {
+		problems = append(problems, "at least one scope is required")
+	}
+	for _, scope := range key.Scopes {
+		if !validScope(Scope(scope)) {
+			problems = append(problems, fmt.Sprintf("unknown scope %q", scope))
+		}
+	}
+
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		problems = append(problems, "expires_at must be in the future")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAPIKey, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validScope(scope Scope) bool {
+	for _, candidate := range AllScopes {
+		if candidate == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if scope == "" || seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		normalized = append(normalized, scope)
+	}
+	return normalized
+}
+
+// generate returns a random lookup prefix and secret.
+func generate() (string, string, error) {
+	buf := make([]byte, prefixBytes+secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generate api key: %w", err)
+	}
+	return hex.EncodeToString(buf[:prefixBytes]), base64.RawURLEncoding.EncodeToString(buf[prefixBytes:]), nil
+}
+
+// format renders a key as gmk_<prefix>_<secret>.
+func format(prefix, secret string) string {
+	return keyPrefix + "_" + prefix + "_" + secret
+}
+
+func parse(raw string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(raw), keyPrefix+"_")
+	if !ok {
+		return "", "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != hex.EncodedLen(prefixBytes) || secret == "" {
+		return "", "", false
+	}
+	return prefix, secret, true
+}
+
+// hash digests a secret for storage. The secret carries 256 bits of randomness, so a fast
+// hash is sufficient and keeps authentication cheap.
+func hash(secret string) []byte {
+	digest := sha256.Sum256([]byte(secret))
+	return digest[:]
+}
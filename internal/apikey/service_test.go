@@ -0,0 +1,175 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepository stores keys by prefix.
+type memoryRepository struct {
+	keys    map[string]*APIKey
+	touched int
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{keys: make(map[string]*APIKey)}
+}
+
+func (m *memoryRepository) Create(_ context.Context, key *APIKey) error {
+	if _, ok := m.keys[key.Prefix]; ok {
+		return errDuplicatePrefix
+	}
+	stored := *key
+	m.keys[key.Prefix] = &stored
+	return nil
+}
+
+func (m *memoryRepository) List(context.Context) ([]APIKey, error) {
+	keys := make([]APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		keys = append(keys, *key)
+	}
+	return keys, nil
+}
+
+func (m *memoryRepository) GetByPrefix(_ context.Context, prefix string) (*APIKey, error) {
+	key, ok := m.keys[prefix]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	found := *key
+	return &found, nil
+}
+
+func (m *memoryRepository) Revoke(_ context.Context, id uuid.UUID) error {
+	for _, key := range m.keys {
+		if key.PublicID == id {
+			now := time.Now()
+			key.RevokedAt = &now
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *memoryRepository) Touch(context.Context, *APIKey, time.Duration) error {
+	m.touched++
+	return nil
+}
+
+func TestParseKey(t *testing.T) {
+	prefix, secret, err := generate()
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	gotPrefix, gotSecret, ok := parse(" " + format(prefix, secret) + "\n")
+	if !ok || gotPrefix != prefix || gotSecret != secret {
+		t.Fatalf("parse(format(...)) = %q, %q, %v", gotPrefix, gotSecret, ok)
+	}
+
+	for _, raw := range []string{
+		"",
+		prefix + "_" + secret,
+		"abc_" + prefix + "_" + secret,
+		"gmk_" + prefix,
+		"gmk_" + prefix + "_",
+		"gmk_abc_" + secret,
+	} {
+		if _, _, ok := parse(raw); ok {
+			t.Errorf("parse(%q) accepted a malformed key", raw)
+		}
+	}
+}
+
+func TestIssueValidatesAndNormalizesScopes(t *testing.T) {
+	service := NewService(newMemoryRepository())
+
+	issued, err := service.Issue(context.Background(), Input{
+		Name:   " Retail partner ",
+		Scopes: []string{"Catalogue:Read", "catalogue:read", " catalogue:write"},
+	})
+	if err != nil {
+		t.Fatalf("Issue returned error: %v", err)
+	}
+	if issued.Name != "Retail partner" || len(issued.Scopes) != 2 {
+		t.Fatalf("issued key = %+v", issued.APIKey)
+	}
+	if !issued.HasScope(ScopeCatalogueRead) || !issued.HasScope(ScopeCatalogueWrite) {
+		t.Fatalf("issued key scopes = %v", issued.Scopes)
+	}
+	if !strings.HasPrefix(issued.Key, "gmk_"+issued.Prefix+"_") {
+		t.Fatalf("issued key %q does not embed prefix %q", issued.Key, issued.Prefix)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name  string
+		input Input
+		want  string
+	}{
+		{name: "missing name", input: Input{Scopes: []string{"catalogue:read"}}, want: "name is required"},
+		{name: "no scopes", input: Input{Name: "partner"}, want: "at least one scope"},
+		{name: "unknown scope", input: Input{Name: "partner", Scopes: []string{"admin"}}, want: `unknown scope "admin"`},
+		{name: "expired", input: Input{Name: "partner", Scopes: []string{"catalogue:read"}, ExpiresAt: &past}, want: "expires_at"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Issue(context.Background(), tt.input)
+			if !errors.Is(err, ErrInvalidAPIKey) || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want ErrInvalidAPIKey mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewService(repo)
+
+	issued, err := service.Issue(context.Background(), Input{Name: "partner", Scopes: []string{"catalogue:read"}})
+	if err != nil {
+		t.Fatalf("Issue returned error: %v", err)
+	}
+
+	key, err := service.Authenticate(context.Background(), issued.Key)
+	if err != nil {
+		t.Fatalf("Authenticate returned error: %v", err)
+	}
+	if key.PublicID != issued.PublicID || !key.HasScope(ScopeCatalogueRead) || key.HasScope(ScopeCatalogueWrite) {
+		t.Fatalf("authenticated key = %+v", key)
+	}
+	if repo.touched != 1 {
+		t.Fatalf("key touched %d times, want 1", repo.touched)
+	}
+
+	_, otherSecret, _ := generate()
+	for name, raw := range map[string]string{
+		"malformed":      "not-a-key",
+		"unknown prefix": format("000000000000", otherSecret),
+		"wrong secret":   format(issued.Prefix, otherSecret),
+	} {
+		if _, err := service.Authenticate(context.Background(), raw); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("%s: error = %v, want ErrUnauthorized", name, err)
+		}
+	}
+
+	expired := time.Now().Add(-time.Second)
+	repo.keys[issued.Prefix].ExpiresAt = &expired
+	if _, err := service.Authenticate(context.Background(), issued.Key); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expired key: error = %v, want ErrUnauthorized", err)
+	}
+
+	repo.keys[issued.Prefix].ExpiresAt = nil
+	if err := service.Revoke(context.Background(), issued.PublicID); err != nil {
+		t.Fatalf("Revoke returned error: %v", err)
+	}
+	if _, err := service.Authenticate(context.Background(), issued.Key); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("revoked key: error = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// maxJWKSBytes bounds the JWKS document read from the identity provider.
+	maxJWKSBytes = 1 << 20
+	// defaultRefreshCooldown limits how often an unknown key ID triggers a refetch, and how
+	// often a failing identity provider is retried, so that neither tokens with made-up key
+	// IDs nor an outage can make every request call the provider.
+	defaultRefreshCooldown = 30 * time.Second
+	// defaultKeysTTL is how long fetched keys are used before they are refreshed anyway.
+	defaultKeysTTL = 12 * time.Hour
+)
+
+// jsonWebKey is the subset of RFC 7517 fields needed for RSA signature keys.
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+// keySet caches the identity provider's signing keys by key ID. Keys are fetched lazily and
+// refetched when a token names a key that is not cached, which is how key rotation shows up.
+type keySet struct {
+	url      string
+	client   *http.Client
+	cooldown time.Duration
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	// attemptedAt and attemptErr record the latest fetch, successful or not, so that the
+	// cooldown also spaces out retries while the provider is failing.
+	attemptedAt time.Time
+	attemptErr  error
+
+	// refresh serialises fetches so concurrent requests with a new key ID share one fetch.
+	refresh sync.Mutex
+}
+
+func newKeySet(url string, client *http.Client, now func() time.Time) *keySet {
+	return &keySet{
+		url:      url,
+		client:   client,
+		cooldown: defaultRefreshCooldown,
+		ttl:      defaultKeysTTL,
+		now:      now,
+	}
+}
+
+// key returns the public key with the given ID, fetching the key set when the ID is unknown
+// or the cached set has expired.
+func (s *keySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	if key, fresh := s.cached(kid); key != nil && fresh {
+		return key, nil
+	}
+
+	s.refresh.Lock()
+	defer s.refresh.Unlock()
+
+	// Another request may have refreshed the set while this one waited.
+	key, fresh := s.cached(kid)
+	if key != nil && fresh {
+		return key, nil
+	}
+
+	s.mu.RLock()
+	recentlyAttempted := !s.attemptedAt.IsZero() && s.now().Sub(s.attemptedAt) < s.cooldown
+	attemptErr := s.attemptErr
+	s.mu.RUnlock()
+	if recentlyAttempted {
+		switch {
+		case key != nil:
+			return key, nil
+		case attemptErr != nil:
+			return nil, attemptErr
+		default:
+			return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidToken, kid)
+		}
+	}
+
+	err := s.fetch(ctx)
+	// A fetch cut short by the caller says nothing about the provider, so it does not
+	// start a cooldown.
+	if ctx.Err() == nil {
+		s.mu.Lock()
+		s.attemptedAt = s.now()
+		s.attemptErr = err
+		s.mu.Unlock()
+	}
+	if err != nil {
+		// Keep serving a known key through a provider outage rather than rejecting every token.
+		if key != nil {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	if key, _ := s.cached(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidToken, kid)
+}
+
+func (s *keySet) cached(kid string) (*rsa.PublicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.keys[kid], s.now().Sub(s.fetchedAt) < s.ttl
+}
+
+func (s *keySet) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("build JWKS request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: fetch JWKS: %v", ErrKeysUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: fetch JWKS: unexpected status %d", ErrKeysUnavailable, resp.StatusCode)
+	}
+
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSBytes)).Decode(&document); err != nil {
+		return fmt.Errorf("%w: decode JWKS: %v", ErrKeysUnavailable, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(document.Keys))
+	for _, jwk := range document.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") || jwk.KeyID == "" {
+			continue
+		}
+		key, err := parseRSAKey(jwk)
+		if err != nil {
+			return fmt.Errorf("%w: key %q: %v", ErrKeysUnavailable, jwk.KeyID, err)
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.fetchedAt = s.now()
+	s.mu.Unlock()
+	return nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode modulus: %w", err)
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode exponent: %w", err)
+	}
+	if len(exponent) == 0 || len(exponent) > 4 {
+		return nil, errors.New("exponent out of range")
+	}
+
+	e := 0
+	for _, b := range exponent {
+		e = e<<8 | int(b)
+	}
+
+	key := &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: e}
+	if key.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("modulus shorter than %d bits", minRSAKeyBits)
+	}
+	return key, nil
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/pkg/requestctx"
+)
+
+// Roles granted through the roles claim.
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+const (
+	// ContextKeyClaims is the Gin context key holding the caller's verified *Claims.
+	ContextKeyClaims = "auth_claims"
+	// ContextKeySubject is the Gin context key holding the caller's subject.
+	ContextKeySubject = "auth_subject"
+	// ContextKeyRoles is the Gin context key holding the caller's roles.
+	ContextKeyRoles = "auth_roles"
+)
+
+// Middleware verifies the bearer token of requests that carry one and stores the caller's
+// subject and roles in the Gin context. Requests without a token continue anonymously, and
+// routes that need a caller enforce that themselves; a token that fails verification is
+// rejected with 401 rather than treated as anonymous.
+func Middleware(verifier *Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorization := strings.TrimSpace(c.GetHeader("Authorization"))
+		if authorization == "" {
+			c.Next()
+			return
+		}
+
+		scheme, token, found := strings.Cut(authorization, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			Unauthorized(c, "authorization header must use the Bearer scheme")
+			return
+		}
+
+		claims, err := verifier.Verify(c.Request.Context(), strings.TrimSpace(token))
+		if err != nil {
+			if errors.Is(err, ErrKeysUnavailable) {
+				c.Error(err)
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "token could not be verified"})
+				return
+			}
+			Unauthorized(c, err.Error())
+			return
+		}
+
+		c.Set(ContextKeyClaims, claims)
+		c.Set(ContextKeySubject, claims.Subject)
+		c.Set(ContextKeyRoles, claims.Roles)
+		c.Request = c.Request.WithContext(requestctx.WithActor(c.Request.Context(), claims.Subject))
+
+		c.Next()
+	}
+}
+
+// ClaimsFrom returns the verified claims stored by Middleware, if the caller presented a token.
+func ClaimsFrom(c *gin.Context) (*Claims, bool) {
+	value, ok := c.Get(ContextKeyClaims)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := value.(*Claims)
+	return claims, ok
+}
+
+// RequireRole admits callers holding at least one of roles. Anonymous callers get 401 and
+// authenticated callers without any of the roles get 403. It must run after Middleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	message := "requires role " + strings.Join(roles, " or ")
+	return func(c *gin.Context) {
+		claims, ok := ClaimsFrom(c)
+		if !ok {
+			Unauthorized(c, "authentication required")
+			return
+		}
+
+		for _, role := range roles {
+			if claims.HasRole(role) {
+				c.Next()
+				return
+			}
+		}
+		Forbidden(c, message)
+	}
+}
+
+// Unauthorized aborts the request with a 401 response. Callers that presented a token are
+// told it was rejected; anonymous callers are challenged to present one.
+func Unauthorized(c *gin.Context, message string) {
+	if c.GetHeader("Authorization") != "" {
+		c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	} else {
+		c.Header("WWW-Authenticate", "Bearer")
+	}
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": message})
+}
+
+// Forbidden aborts the request with a 403 response for an authenticated caller who lacks
+// the required role.
+func Forbidden(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer error="insufficient_scope"`)
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": message})
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		claims    *Claims
+		token     bool
+		want      int
+		challenge string
+	}{
+		{name: "anonymous", want: http.StatusUnauthorized, challenge: "Bearer"},
+		{name: "missing role", claims: &Claims{Subject: "auth0|bob"}, token: true, want: http.StatusForbidden, challenge: `Bearer error="insufficient_scope"`},
+		{name: "other role", claims: &Claims{Subject: "auth0|bob", Roles: []string{"guest"}}, token: true, want: http.StatusForbidden, challenge: `Bearer error="insufficient_scope"`},
+		{name: "one of the roles", claims: &Claims{Subject: "auth0|alice", Roles: []string{RoleMember}}, token: true, want: http.StatusOK},
+		{name: "admin", claims: &Claims{Subject: "auth0|carol", Roles: []string{RoleAdmin}}, token: true, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				if tt.claims != nil {
+					c.Set(ContextKeyClaims, tt.claims)
+				}
+			})
+			engine.GET("/tastings", RequireRole(RoleMember, RoleAdmin), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/tastings", nil)
+			if tt.token {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if got := rec.Header().Get("WWW-Authenticate"); got != tt.challenge {
+				t.Fatalf("WWW-Authenticate = %q, want %q", got, tt.challenge)
+			}
+		})
+	}
+}
+
+func TestMiddlewareRejectsMalformedAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := NewVerifier(Config{Domain: testDomain, Audience: testAudience, JWKSURL: "http://127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+
+	engine := gin.New()
+	engine.Use(Middleware(verifier))
+	engine.GET("/gins", func(c *gin.Context) {
+		if _, ok := ClaimsFrom(c); ok {
+			t.Error("anonymous request carries claims")
+		}
+		c.Status(http.StatusOK)
+	})
+
+	for header, want := range map[string]int{
+		"":             http.StatusOK,
+		"Basic abc":    http.StatusUnauthorized,
+		"Bearer ":      http.StatusUnauthorized,
+		"Bearer a.b.c": http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/gins", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != want {
+			t.Errorf("Authorization %q: status = %d, want %d", header, rec.Code, want)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRolesClaim is the namespaced custom claim that carries a user's roles.
+	DefaultRolesClaim = "https://ginmania.app/roles"
+	// minRSAKeyBits rejects signing keys too short to be trusted.
+	minRSAKeyBits = 2048
+	// defaultLeeway tolerates small clock differences with the identity provider.
+	defaultLeeway = 30 * time.Second
+)
+
+var (
+	// ErrInvalidToken is returned when a bearer token is malformed, has a bad signature or
+	// fails a claim check.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrKeysUnavailable is returned when the signing keys cannot be fetched from the
+	// identity provider.
+	ErrKeysUnavailable = errors.New("signing keys unavailable")
+)
+
+// Config describes the identity provider whose tokens a Verifier accepts.
+type Config struct {
+	// Domain is the Auth0 tenant domain, such as "gin-mania.eu.auth0.com". It determines the
+	// expected issuer and the default JWKS URL.
+	Domain string
+	// Audience is the API identifier that tokens must be issued for.
+	Audience string
+	// JWKSURL overrides the key set location, for example to point at a local stub server.
+	JWKSURL string
+	// HTTPClient fetches the key set. Defaults to a client with a 10 second timeout.
+	HTTPClient *http.Client
+	// RolesClaim names the claim listing the user's roles. Defaults to DefaultRolesClaim.
+	RolesClaim string
+	// Now reports the current time for expiry checks. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Claims are the verified identity claims of a token.
+type Claims struct {
+	Subject   string
+	Email     string
+	Name      string
+	Roles     []string
+	ExpiresAt time.Time
+}
+
+// HasRole reports whether the claims grant role.
+func (c *Claims) HasRole(role string) bool {
+	for _, candidate := range c.Roles {
+		if candidate == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Verifier checks RS256-signed access tokens against the identity provider's published keys.
+type Verifier struct {
+	keys       *keySet
+	issuer     string
+	audience   string
+	rolesClaim string
+	now        func() time.Time
+}
+
+// NewVerifier constructs a Verifier for the configured identity provider. Keys are fetched
+// on first use, so construction does not require network access.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	domain := strings.TrimSuffix(strings.TrimSpace(cfg.Domain), "/")
+	if domain == "" {
+		return nil, errors.New("auth domain is required")
+	}
+	if cfg.Audience == "" {
+		return nil, errors.New("auth audience is required")
+	}
+
+	jwksURL := cfg.JWKSURL
+	if jwksURL == "" {
+		jwksURL = "https://" + domain + "/.well-known/jwks.json"
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	rolesClaim := cfg.RolesClaim
+	if rolesClaim == "" {
+		rolesClaim = DefaultRolesClaim
+	}
+	now := cfg.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Verifier{
+		keys:       newKeySet(jwksURL, client, now),
+		issuer:     "https://" + domain + "/",
+		audience:   cfg.Audience,
+		rolesClaim: rolesClaim,
+		now:        now,
+	}, nil
+}
+
+// header is the JOSE header of a compact JWS.
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// registeredClaims holds the standard claims checked for every token.
+type registeredClaims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+	Email     string   `json:"email"`
+	Name      string   `json:"name"`
+}
+
+// audience accepts the aud claim as either a single string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, candidate := range a {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify checks the token's signature, issuer, audience and validity period and returns
+// its claims.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var head header
+	if err := decodeSegment(parts[0], &head); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	// Only RS256 is accepted, which rules out "none" and HMAC algorithm confusion.
+	if head.Algorithm != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, head.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature encoding", ErrInvalidToken)
+	}
+
+	key, err := v.keys.key(ctx, head.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("%w: payload: %v", ErrInvalidToken, err)
+	}
+	var registered registeredClaims
+	if err := decodeSegment(parts[1], &registered); err != nil {
+		return nil, fmt.Errorf("%w: payload: %v", ErrInvalidToken, err)
+	}
+
+	now := v.now()
+	switch {
+	case registered.Issuer != v.issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	case !registered.Audience.contains(v.audience):
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	case registered.ExpiresAt == nil:
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidToken)
+	case now.After(time.Unix(*registered.ExpiresAt, 0).Add(defaultLeeway)):
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidToken)
+	case registered.NotBefore != nil && now.Add(defaultLeeway).Before(time.Unix(*registered.NotBefore, 0)):
+		return nil, fmt.Errorf("%w: token not yet valid", ErrInvalidToken)
+	case registered.Subject == "":
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	var roles []string
+	if value, ok := raw[v.rolesClaim]; ok {
+		if err := json.Unmarshal(value, &roles); err != nil {
+			return nil, fmt.Errorf("%w: roles claim must be an array of strings", ErrInvalidToken)
+		}
+	}
+
+	return &Claims{
+		Subject:   registered.Subject,
+		Email:     registered.Email,
+		Name:      registered.Name,
+		Roles:     roles,
+		ExpiresAt: time.Unix(*registered.ExpiresAt, 0),
+	}, nil
+}
+
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
@@ -0,0 +1,378 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testDomain   = "gin-mania.test.auth0.com"
+	testAudience = "https://api.ginmania.test"
+)
+
+var (
+	testKeysOnce sync.Once
+	testKeys     map[string]*rsa.PrivateKey
+)
+
+// signingKey returns a lazily generated RSA key for kid, shared by every test.
+func signingKey(t *testing.T, kid string) *rsa.PrivateKey {
+	t.Helper()
+	testKeysOnce.Do(func() {
+		testKeys = make(map[string]*rsa.PrivateKey)
+		for _, id := range []string{"key-1", "key-2"} {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				panic(err)
+			}
+			testKeys[id] = key
+		}
+	})
+	key, ok := testKeys[kid]
+	if !ok {
+		t.Fatalf("no test key %q", kid)
+	}
+	return key
+}
+
+// stubJWKS is a local identity provider key endpoint whose keys and availability tests
+// change as they go.
+type stubJWKS struct {
+	server  *httptest.Server
+	fetches atomic.Int32
+
+	mu   sync.Mutex
+	kids []string
+	down bool
+}
+
+func newStubJWKS(t *testing.T, kids ...string) *stubJWKS {
+	t.Helper()
+	stub := &stubJWKS{kids: kids}
+	stub.server = httptest.NewServer(http.HandlerFunc(stub.serve))
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+func (s *stubJWKS) serve(w http.ResponseWriter, _ *http.Request) {
+	s.fetches.Add(1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.down {
+		http.Error(w, "unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	keys := make([]jsonWebKey, 0, len(s.kids))
+	for _, kid := range s.kids {
+		public := testKeys[kid].PublicKey
+		keys = append(keys, jsonWebKey{
+			KeyType: "RSA",
+			KeyID:   kid,
+			Use:     "sig",
+			N:       base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		})
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+}
+
+func (s *stubJWKS) set(down bool, kids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.down = down
+	s.kids = kids
+}
+
+// clock is a manually advanced time source.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestVerifier(t *testing.T, stub *stubJWKS, now *clock) *Verifier {
+	t.Helper()
+	verifier, err := NewVerifier(Config{
+		Domain:     testDomain,
+		Audience:   testAudience,
+		JWKSURL:    stub.server.URL,
+		HTTPClient: stub.server.Client(),
+		Now:        now.Now,
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	return verifier
+}
+
+// validClaims returns the claims of a token the test verifier accepts at now.
+func validClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":             "https://" + testDomain + "/",
+		"sub":             "auth0|alice",
+		"aud":             []string{testAudience, "https://" + testDomain + "/userinfo"},
+		"exp":             now.Add(time.Hour).Unix(),
+		"email":           "alice@example.com",
+		"name":            "Alice",
+		DefaultRolesClaim: []string{RoleMember},
+	}
+}
+
+func signToken(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	encode := func(value interface{}) string {
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("encode token segment: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signingInput := encode(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, signingKey(t, kid), crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifyAcceptsValidToken(t *testing.T) {
+	signingKey(t, "key-1")
+	stub := newStubJWKS(t, "key-1")
+	now := &clock{now: time.Now()}
+	verifier := newTestVerifier(t, stub, now)
+
+	claims, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", validClaims(now.Now())))
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if claims.Subject != "auth0|alice" || claims.Email != "alice@example.com" || claims.Name != "Alice" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if !claims.HasRole(RoleMember) || claims.HasRole(RoleAdmin) {
+		t.Errorf("roles = %v, want [member]", claims.Roles)
+	}
+
+	// Later tokens are verified against the cached keys.
+	if _, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", validClaims(now.Now()))); err != nil {
+		t.Fatalf("second Verify returned error: %v", err)
+	}
+	if got := stub.fetches.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", got)
+	}
+}
+
+func TestVerifyRejectsInvalidClaims(t *testing.T) {
+	signingKey(t, "key-1")
+	stub := newStubJWKS(t, "key-1")
+	now := &clock{now: time.Now()}
+	verifier := newTestVerifier(t, stub, now)
+
+	tests := []struct {
+		name   string
+		modify func(claims map[string]interface{})
+		want   string
+	}{
+		{name: "wrong issuer", modify: func(c map[string]interface{}) { c["iss"] = "https://evil.example.com/" }, want: "unexpected issuer"},
+		{name: "wrong audience", modify: func(c map[string]interface{}) { c["aud"] = "https://other.api" }, want: "unexpected audience"},
+		{name: "expired", modify: func(c map[string]interface{}) { c["exp"] = now.Now().Add(-time.Minute).Unix() }, want: "token expired"},
+		{name: "missing expiry", modify: func(c map[string]interface{}) { delete(c, "exp") }, want: "missing expiry"},
+		{name: "not yet valid", modify: func(c map[string]interface{}) { c["nbf"] = now.Now().Add(time.Minute).Unix() }, want: "not yet valid"},
+		{name: "missing subject", modify: func(c map[string]interface{}) { delete(c, "sub") }, want: "missing subject"},
+		{name: "malformed roles", modify: func(c map[string]interface{}) { c[DefaultRolesClaim] = "admin" }, want: "roles claim"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims(now.Now())
+			tt.modify(claims)
+
+			_, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", claims))
+			if !errors.Is(err, ErrInvalidToken) || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want ErrInvalidToken mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyToleratesClockSkew(t *testing.T) {
+	signingKey(t, "key-1")
+	stub := newStubJWKS(t, "key-1")
+	now := &clock{now: time.Now()}
+	verifier := newTestVerifier(t, stub, now)
+
+	claims := validClaims(now.Now())
+	claims["exp"] = now.Now().Add(-defaultLeeway / 2).Unix()
+	if _, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", claims)); err != nil {
+		t.Fatalf("Verify returned error within leeway: %v", err)
+	}
+}
+
+func TestVerifyRejectsOtherAlgorithms(t *testing.T) {
+	signingKey(t, "key-1")
+	stub := newStubJWKS(t, "key-1")
+	now := &clock{now: time.Now()}
+	verifier := newTestVerifier(t, stub, now)
+
+	valid := signToken(t, "RS256", "key-1", validClaims(now.Now()))
+	_, payload, _ := strings.Cut(valid, ".")
+	payload, signature, _ := strings.Cut(payload, ".")
+
+	for _, alg := range []string{"none", "HS256", "RS384", "ES256", "PS256"} {
+		t.Run(alg, func(t *testing.T) {
+			head, _ := json.Marshal(map[string]string{"alg": alg, "kid": "key-1"})
+			token := base64.RawURLEncoding.EncodeToString(head) + "." + payload + "." + signature
+
+			_, err := verifier.Verify(context.Background(), token)
+			if !errors.Is(err, ErrInvalidToken) || !strings.Contains(err.Error(), "unsupported algorithm") {
+				t.Fatalf("error = %v, want unsupported algorithm", err)
+			}
+		})
+	}
+	if got := stub.fetches.Load(); got != 0 {
+		t.Errorf("JWKS fetched %d times for rejected algorithms, want 0", got)
+	}
+}
+
+func TestVerifyRejectsBadSignature(t *testing.T) {
+	signingKey(t, "key-1")
+	stub := newStubJWKS(t, "key-1")
+	now := &clock{now: time.Now()}
+	verifier := newTestVerifier(t, stub, now)
+
+	// Signed by key-2 but claiming to be key-1.
+	token := signToken(t, "RS256", "key-2", validClaims(now.Now()))
+	token = strings.Replace(token, strings.SplitN(token, ".", 2)[0], base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"key-1"}`)), 1)
+
+	if _, err := verifier.Verify(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestVerifyRefreshesKeysForUnknownKeyID(t *testing.T) {
+	signingKey(t, "key-1")
+	stub := newStubJWKS(t, "key-1")
+	now := &clock{now: time.Now()}
+	verifier := newTestVerifier(t, stub, now)
+
+	if _, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", validClaims(now.Now()))); err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	// The provider rotates to key-2 shortly after the first fetch. Within the cooldown an
+	// unknown key ID is rejected without calling the provider again.
+	stub.set(false, "key-1", "key-2")
+	now.advance(time.Second)
+	rotated := signToken(t, "RS256", "key-2", validClaims(now.Now()))
+	if _, err := verifier.Verify(context.Background(), rotated); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("error within cooldown = %v, want ErrInvalidToken", err)
+	}
+	if got := stub.fetches.Load(); got != 1 {
+		t.Fatalf("JWKS fetched %d times within cooldown, want 1", got)
+	}
+
+	// Once the cooldown has passed the unknown key ID triggers a refetch.
+	now.advance(defaultRefreshCooldown)
+	if _, err := verifier.Verify(context.Background(), rotated); err != nil {
+		t.Fatalf("Verify after cooldown returned error: %v", err)
+	}
+	if got := stub.fetches.Load(); got != 2 {
+		t.Fatalf("JWKS fetched %d times, want 2", got)
+	}
+
+	// A key ID the provider does not know is still rejected after the refetch.
+	now.advance(defaultRefreshCooldown)
+	claims := validClaims(now.Now())
+	head, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "key-3"})
+	_, rest, _ := strings.Cut(signToken(t, "RS256", "key-1", claims), ".")
+	if _, err := verifier.Verify(context.Background(), base64.RawURLEncoding.EncodeToString(head)+"."+rest); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("error for unknown key = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestVerifyServesStaleKeysDuringOutage(t *testing.T) {
+	signingKey(t, "key-1")
+	stub := newStubJWKS(t, "key-1")
+	now := &clock{now: time.Now()}
+	verifier := newTestVerifier(t, stub, now)
+
+	if _, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", validClaims(now.Now()))); err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	// The cached keys expire while the provider is down: the known key keeps working.
+	stub.set(true)
+	now.advance(defaultKeysTTL + time.Minute)
+	if _, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", validClaims(now.Now()))); err != nil {
+		t.Fatalf("Verify during outage returned error: %v", err)
+	}
+	if got := stub.fetches.Load(); got != 2 {
+		t.Fatalf("JWKS fetched %d times, want 2", got)
+	}
+
+	// Further requests within the cooldown use the stale key without retrying the provider.
+	now.advance(time.Second)
+	if _, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", validClaims(now.Now()))); err != nil {
+		t.Fatalf("Verify during outage returned error: %v", err)
+	}
+	if got := stub.fetches.Load(); got != 2 {
+		t.Fatalf("JWKS fetched %d times within cooldown, want 2", got)
+	}
+}
+
+func TestVerifyReportsUnavailableKeys(t *testing.T) {
+	signingKey(t, "key-1")
+	stub := newStubJWKS(t)
+	stub.set(true)
+	now := &clock{now: time.Now()}
+	verifier := newTestVerifier(t, stub, now)
+
+	// While the provider fails, it is asked at most once per cooldown window.
+	for window := int32(1); window <= 2; window++ {
+		for i := 0; i < 3; i++ {
+			_, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", validClaims(now.Now())))
+			if !errors.Is(err, ErrKeysUnavailable) {
+				t.Fatalf("error = %v, want ErrKeysUnavailable", err)
+			}
+			now.advance(time.Second)
+		}
+		if got := stub.fetches.Load(); got != window {
+			t.Fatalf("JWKS fetched %d times in %d cooldown windows, want %d", got, window, window)
+		}
+		now.advance(defaultRefreshCooldown)
+	}
+
+	// The first request after the provider recovers and the cooldown has passed succeeds.
+	stub.set(false, "key-1")
+	if _, err := verifier.Verify(context.Background(), signToken(t, "RS256", "key-1", validClaims(now.Now()))); err != nil {
+		t.Fatalf("Verify after recovery returned error: %v", err)
+	}
+}
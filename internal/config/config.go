@@ -26,6 +26,7 @@ type Config struct {
 	Database DatabaseConfig
 	Redis    RedisConfig
 	Auth     AuthConfig
+	Jobs     JobsConfig
 	Logging  logging.Config
 }
 
@@ -40,6 +41,7 @@ type ServerConfig struct {
 	GinMode         string
 	ReadTimeout     time.Duration
 	WriteTimeout    time.Duration
+	ExportTimeout   time.Duration
 	ShutdownTimeout time.Duration
 	AllowedOrigins  []string
 }
@@ -63,6 +65,22 @@ type AuthConfig struct {
 	Enabled  bool
 	Domain   string
 	Audience string
+	// JWKSURL overrides the tenant's key set location, for example to use a local stub.
+	JWKSURL string
+	// Disabled runs without authentication: every request is anonymous and role checks are
+	// skipped. It requires APP_ENV=development or an explicit AUTH_DISABLED=true and is never
+	// allowed in production; otherwise a missing Auth0 configuration is an error.
+	Disabled bool
+	// TrustActorHeader identifies callers by the unverified X-Actor header while Auth0 is
+	// disabled, so that local development can attribute writes to a user.
+	TrustActorHeader bool
+}
+
+// JobsConfig tunes the in-process background job runner.
+type JobsConfig struct {
+	Workers       int
+	PollInterval  time.Duration
+	LeaseDuration time.Duration
 }
 
 // Load constructs a Config instance by reading environment variables and applying defaults.
@@ -84,7 +102,12 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
-	authCfg, err := loadAuthConfig()
+	authCfg, err := loadAuthConfig(appEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	jobsCfg, err := loadJobsConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +125,7 @@ func Load() (*Config, error) {
 		Database: database,
 		Redis:    redisCfg,
 		Auth:     authCfg,
+		Jobs:     jobsCfg,
 		Logging:  loggingCfg,
 	}, nil
 }
@@ -144,6 +168,11 @@ func loadServerConfig(appEnv string) (ServerConfig, error) {
 		return ServerConfig{}, err
 	}
 
+	exportTimeout, err := parseDuration("SERVER_EXPORT_TIMEOUT", 10*time.Minute)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+
 	shutdownTimeout, err := parseDuration("SERVER_SHUTDOWN_TIMEOUT", 10*time.Second)
 	if err != nil {
 		return ServerConfig{}, err
@@ -155,6 +184,7 @@ func loadServerConfig(appEnv string) (ServerConfig, error) {
 	cfg.GinMode = ginMode
 	cfg.ReadTimeout = readTimeout
 	cfg.WriteTimeout = writeTimeout
+	cfg.ExportTimeout = exportTimeout
 	cfg.ShutdownTimeout = shutdownTimeout
 	cfg.AllowedOrigins = allowedOrigins
 
@@ -221,15 +251,35 @@ func loadRedisConfig() (RedisConfig, error) {
 	return RedisConfig{URL: redisURL}, nil
 }
 
-func loadAuthConfig() (AuthConfig, error) {
+func loadAuthConfig(appEnv string) (AuthConfig, error) {
 	enabled := parseBool("AUTH0_ENABLED", false)
 	domain := strings.TrimSpace(os.Getenv("AUTH0_DOMAIN"))
 	audience := strings.TrimSpace(os.Getenv("AUTH0_AUDIENCE"))
+	jwksURL := strings.TrimSpace(os.Getenv("AUTH0_JWKS_URL"))
+	trustActorHeader := parseBool("AUTH_TRUST_ACTOR_HEADER", false)
+	disabled := parseBool("AUTH_DISABLED", false)
 
 	if !enabled {
 		enabled = domain != "" && audience != ""
 	}
 
+	if disabled && enabled {
+		return AuthConfig{}, errors.New("AUTH_DISABLED cannot be combined with an Auth0 configuration")
+	}
+
+	if !enabled && strings.EqualFold(appEnv, "production") {
+		return AuthConfig{}, errors.New("Auth0 must be configured in production: set AUTH0_DOMAIN and AUTH0_AUDIENCE")
+	}
+
+	// Fail closed: running without authentication is an explicit choice outside development.
+	if !enabled && !disabled && !strings.EqualFold(appEnv, "development") {
+		return AuthConfig{}, errors.New("Auth0 is not configured: set AUTH0_DOMAIN and AUTH0_AUDIENCE, or AUTH_DISABLED=true to run without authentication")
+	}
+
+	if trustActorHeader && (enabled || strings.EqualFold(appEnv, "production")) {
+		return AuthConfig{}, errors.New("AUTH_TRUST_ACTOR_HEADER is only allowed outside production with Auth0 disabled")
+	}
+
 	if enabled {
 		if domain == "" {
 			return AuthConfig{}, errors.New("AUTH0_DOMAIN is required when Auth0 is enabled")
@@ -240,12 +290,52 @@ func loadAuthConfig() (AuthConfig, error) {
 		if strings.Contains(domain, "://") {
 			return AuthConfig{}, errors.New("AUTH0_DOMAIN should not include a scheme (https://)")
 		}
+		if jwksURL != "" {
+			if parsed, err := url.Parse(jwksURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return AuthConfig{}, errors.New("AUTH0_JWKS_URL must be an absolute http or https URL")
+			}
+		}
 	}
 
 	return AuthConfig{
-		Enabled:  enabled,
-		Domain:   domain,
-		Audience: audience,
+		Enabled:          enabled,
+		Domain:           domain,
+		Audience:         audience,
+		JWKSURL:          jwksURL,
+		Disabled:         !enabled,
+		TrustActorHeader: trustActorHeader,
+	}, nil
+}
+
+func loadJobsConfig() (JobsConfig, error) {
+	workers, err := parseInt("JOB_WORKERS", 2)
+	if err != nil {
+		return JobsConfig{}, err
+	}
+	if workers < 1 {
+		return JobsConfig{}, errors.New("JOB_WORKERS must be at least 1")
+	}
+
+	pollInterval, err := parseDuration("JOB_POLL_INTERVAL", time.Second)
+	if err != nil {
+		return JobsConfig{}, err
+	}
+	if pollInterval == 0 {
+		return JobsConfig{}, errors.New("JOB_POLL_INTERVAL must be positive")
+	}
+
+	leaseDuration, err := parseDuration("JOB_LEASE_DURATION", time.Minute)
+	if err != nil {
+		return JobsConfig{}, err
+	}
+	if leaseDuration < 3*time.Second {
+		return JobsConfig{}, errors.New("JOB_LEASE_DURATION must be at least 3s")
+	}
+
+	return JobsConfig{
+		Workers:       workers,
+		PollInterval:  pollInterval,
+		LeaseDuration: leaseDuration,
 	}, nil
 }
 
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadAuthConfigFailsClosed(t *testing.T) {
+	tests := []struct {
+		name         string
+		appEnv       string
+		env          map[string]string
+		wantDisabled bool
+		wantErr      string
+	}{
+		{name: "development without Auth0", appEnv: "development", wantDisabled: true},
+		{name: "staging without Auth0", appEnv: "staging", wantErr: "AUTH_DISABLED=true"},
+		{name: "staging opting out", appEnv: "staging", env: map[string]string{"AUTH_DISABLED": "true"}, wantDisabled: true},
+		{name: "production opting out", appEnv: "production", env: map[string]string{"AUTH_DISABLED": "true"}, wantErr: "production"},
+		{name: "staging with Auth0", appEnv: "staging", env: map[string]string{"AUTH0_DOMAIN": "tenant.auth0.com", "AUTH0_AUDIENCE": "https://api"}},
+		{
+			name:    "opting out with Auth0",
+			appEnv:  "development",
+			env:     map[string]string{"AUTH0_DOMAIN": "tenant.auth0.com", "AUTH0_AUDIENCE": "https://api", "AUTH_DISABLED": "true"},
+			wantErr: "AUTH_DISABLED cannot be combined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"AUTH0_ENABLED", "AUTH0_DOMAIN", "AUTH0_AUDIENCE", "AUTH0_JWKS_URL", "AUTH_TRUST_ACTOR_HEADER", "AUTH_DISABLED"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg, err := loadAuthConfig(tt.appEnv)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadAuthConfig returned error: %v", err)
+			}
+			if cfg.Disabled != tt.wantDisabled || cfg.Enabled == tt.wantDisabled {
+				t.Fatalf("config = %+v, want disabled %v", cfg, tt.wantDisabled)
+			}
+		})
+	}
+}
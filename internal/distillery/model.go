@@ -0,0 +1,25 @@
+package distillery
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Distillery represents a producer that gin rows reference through gin.distillery_id.
+type Distillery struct {
+	ID          uint      `json:"-" gorm:"column:id;primaryKey"`
+	PublicID    uuid.UUID `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	Name        string    `json:"name" gorm:"column:name;type:varchar(255);not null"`
+	Country     string    `json:"country" gorm:"column:country;type:varchar(255);not null"`
+	Region      string    `json:"region" gorm:"column:region;type:varchar(255);not null"`
+	FoundedYear *int      `json:"founded_year" gorm:"column:founded_year;type:smallint"`
+	Website     string    `json:"website" gorm:"column:website;type:text;not null"`
+	CreatedAt   time.Time `json:"-" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"-" gorm:"column:updated_at"`
+}
+
+// TableName specifies the PostgreSQL table name for distilleries.
+func (Distillery) TableName() string {
+	return "distilleries"
+}
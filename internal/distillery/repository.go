@@ -0,0 +1,127 @@
+package distillery
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gin-mania-backend/pkg/database"
+)
+
+// Repository defines access methods to distillery storage.
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Distillery, int64, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Distillery, error)
+	Create(ctx context.Context, distillery *Distillery) error
+	Update(ctx context.Context, distillery *Distillery) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ListFilter represents filtering and pagination options for listing distilleries.
+type ListFilter struct {
+	Query   string
+	Country string
+	Limit   int
+	Offset  int
+}
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+// NewRepository constructs a Repository backed by GORM.
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) List(ctx context.Context, filter ListFilter) ([]Distillery, int64, error) {
+	var distilleries []Distillery
+	var total int64
+
+	if err := applyListFilter(r.db.WithContext(ctx).Model(&Distillery{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tx := applyListFilter(r.db.WithContext(ctx).Model(&Distillery{}), filter)
+
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		tx = tx.Offset(filter.Offset)
+	}
+
+	if err := tx.Order("name ASC, id ASC").Find(&distilleries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return distilleries, total, nil
+}
+
+func applyListFilter(tx *gorm.DB, filter ListFilter) *gorm.DB {
+	if query := strings.TrimSpace(filter.Query); query != "" {
+		tx = tx.Where(`LOWER(name) LIKE ? ESCAPE '\'`, "%"+database.EscapeLike(strings.ToLower(query))+"%")
+	}
+
+	if country := strings.TrimSpace(filter.Country); country != "" {
+		tx = tx.Where("LOWER(country) = ?", strings.ToLower(country))
+	}
+
+	return tx
+}
+
+func (r *gormRepository) GetByID(ctx context.Context, id uuid.UUID) (*Distillery, error) {
+	var distillery Distillery
+
+	err := r.db.WithContext(ctx).Where("public_id = ?", id).Take(&distillery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &distillery, nil
+}
+
+func (r *gormRepository) Create(ctx context.Context, distillery *Distillery) error {
+	if err := r.db.WithContext(ctx).Create(distillery).Error; err != nil {
+		if database.IsUniqueViolation(err) {
+			return ErrDuplicateName
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormRepository) Update(ctx context.Context, distillery *Distillery) error {
+	result := r.db.WithContext(ctx).
+		Model(distillery).
+		Select("name", "country", "region", "founded_year", "website", "updated_at").
+		Updates(distillery)
+	if result.Error != nil {
+		if database.IsUniqueViolation(result.Error) {
+			return ErrDuplicateName
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("public_id = ?", id).Delete(&Distillery{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package distillery
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrRepositoryNotConfigured indicates that the service was constructed without a backing repository.
+	ErrRepositoryNotConfigured = errors.New("distillery repository not configured")
+	// ErrInvalidPagination is returned when the requested pagination parameters are negative.
+	ErrInvalidPagination = errors.New("invalid pagination parameters")
+	// ErrNotFound is returned when the requested distillery does not exist.
+	ErrNotFound = errors.New("distillery not found")
+	// ErrInvalidDistillery is returned when a create or update request fails validation.
+	ErrInvalidDistillery = errors.New("invalid distillery")
+	// ErrDuplicateName is returned when another distillery already uses the requested name.
+	ErrDuplicateName = errors.New("distillery name already exists")
+)
+
+// Service provides distillery management backed by a repository implementation.
+type Service struct {
+	repo Repository
+}
+
+// NewService constructs a new Service using the provided repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// List returns distilleries matching the filter along with the total number of matches.
+func (s *Service) List(ctx context.Context, filter ListFilter) ([]Distillery, int64, error) {
+	if s.repo == nil {
+		return nil, 0, ErrRepositoryNotConfigured
+	}
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	return s.repo.List(ctx, filter)
+}
+
+// Get retrieves a single distillery by its public identifier.
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*Distillery, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// Create validates the input and persists a new distillery.
+func (s *Service) Create(ctx context.Context, input Input) (*Distillery, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	distillery := &Distillery{PublicID: uuid.New()}
+	input.apply(distillery)
+	if err := validate(distillery); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, distillery); err != nil {
+		return nil, err
+	}
+	return distillery, nil
+}
+
+// Update replaces all writable attributes of an existing distillery.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, input Input) (*Distillery, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	distillery, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	input.apply(distillery)
+	if err := validate(distillery); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, distillery); err != nil {
+		return nil, err
+	}
+	return distillery, nil
+}
+
+// Delete removes a distillery. Gins that referenced it are kept without a distillery.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	if s.repo == nil {
+		return ErrRepositoryNotConfigured
+	}
+
+	return s.repo.Delete(ctx, id)
+}
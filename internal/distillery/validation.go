@@ -0,0 +1,66 @@
+package distillery
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxVarcharLength = 255
+	minFoundedYear   = 1000
+)
+
+// Input carries the writable attributes of a distillery for create and full-replace updates.
+type Input struct {
+	Name        string `json:"name"`
+	Country     string `json:"country"`
+	Region      string `json:"region"`
+	FoundedYear *int   `json:"founded_year"`
+	Website     string `json:"website"`
+}
+
+func (in Input) apply(d *Distillery) {
+	d.Name = strings.Join(strings.Fields(in.Name), " ")
+	d.Country = strings.TrimSpace(in.Country)
+	d.Region = strings.TrimSpace(in.Region)
+	d.FoundedYear = in.FoundedYear
+	d.Website = strings.TrimSpace(in.Website)
+}
+
+func validate(d *Distillery) error {
+	var problems []string
+
+	if d.Name == "" {
+		problems = append(problems, "name is required")
+	} else if utf8.RuneCountInString(d.Name) > maxVarcharLength {
+		problems = append(problems, fmt.Sprintf("name must be at most %d characters", maxVarcharLength))
+	}
+
+	if utf8.RuneCountInString(d.Country) > maxVarcharLength {
+		problems = append(problems, fmt.Sprintf("country must be at most %d characters", maxVarcharLength))
+	}
+
+	if utf8.RuneCountInString(d.Region) > maxVarcharLength {
+		problems = append(problems, fmt.Sprintf("region must be at most %d characters", maxVarcharLength))
+	}
+
+	if d.FoundedYear != nil {
+		if year := *d.FoundedYear; year < minFoundedYear || year > time.Now().Year() {
+			problems = append(problems, fmt.Sprintf("founded_year must be between %d and the current year", minFoundedYear))
+		}
+	}
+
+	if d.Website != "" {
+		if parsed, err := url.Parse(d.Website); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			problems = append(problems, "website must be an absolute http or https URL")
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidDistillery, strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package router
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/search"
+)
+
+// adminGinsHandler lists gins like the public search but across publication statuses. The
+// status parameter narrows the listing and accepts several values; all statuses are listed
+// by default.
+func adminGinsHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter, err := parseSearchFilter(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		filter.Statuses = search.AllStatuses
+		if values := parseListQuery(c, "status"); len(values) > 0 {
+			filter.Statuses = make([]search.Status, 0, len(values))
+			for _, value := range values {
+				status, err := search.ParseStatus(value)
+				if err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of draft, published, archived"})
+					return
+				}
+				filter.Statuses = append(filter.Statuses, status)
+			}
+		}
+
+		respondSearch(c, service, filter)
+	}
+}
+
+func adminGinDetailHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		result, err := service.Get(c.Request.Context(), id)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func createGinHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input search.GinInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Create(c.Request.Context(), input)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, result)
+	}
+}
+
+func updateGinHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		var input search.GinInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Update(c.Request.Context(), id, input)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func patchGinHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		var patch search.GinPatch
+		if err := c.ShouldBindJSON(&patch); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Patch(c.Request.Context(), id, patch)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func archiveGinHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		result, err := service.Archive(c.Request.Context(), id)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func deleteGinHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		if err := service.Delete(c.Request.Context(), id); err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func deletedGinsHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var limit, offset int
+
+		if limitStr := c.Query("limit"); limitStr != "" {
+			parsed, err := strconv.Atoi(limitStr)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+				return
+			}
+			limit = parsed
+		}
+
+		if offsetStr := c.Query("offset"); offsetStr != "" {
+			parsed, err := strconv.Atoi(offsetStr)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+				return
+			}
+			offset = parsed
+		}
+
+		items, total, err := service.ListDeleted(c.Request.Context(), limit, offset)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"total":  total,
+			"items":  items,
+		})
+	}
+}
+
+func restoreGinHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		result, err := service.Restore(c.Request.Context(), id)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func ginHistoryHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		revisions, err := service.History(c.Request.Context(), id)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+		if revisions == nil {
+			revisions = []search.Revision{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"items": revisions})
+	}
+}
+
+func revertGinHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		var request struct {
+			Revision int `json:"revision" binding:"required,min=1"`
+		}
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "revision must be a positive integer"})
+			return
+		}
+
+		result, err := service.Revert(c.Request.Context(), id, request.Revision)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
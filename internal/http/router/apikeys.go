@@ -0,0 +1,144 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/apikey"
+	"gin-mania-backend/internal/auth"
+	"gin-mania-backend/pkg/requestctx"
+)
+
+const (
+	// ContextKeyAPIKey is the Gin context key holding the *apikey.APIKey a request used.
+	ContextKeyAPIKey = "api_key"
+	apiKeyHeader     = "X-API-Key"
+)
+
+// apiKeyMiddleware authenticates requests that carry an X-API-Key header. The key becomes
+// the request's actor and its scopes are checked by the route guards. Requests without the
+// header pass through to token authentication.
+func apiKeyMiddleware(service *apikey.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.GetHeader(apiKeyHeader)
+		if raw == "" {
+			c.Next()
+			return
+		}
+		if c.GetHeader("Authorization") != "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "send either an API key or a bearer token, not both"})
+			return
+		}
+
+		key, err := service.Authenticate(c.Request.Context(), raw)
+		if err != nil {
+			if errors.Is(err, apikey.ErrUnauthorized) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			}
+			c.Error(err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "api key could not be verified"})
+			return
+		}
+
+		c.Set(ContextKeyAPIKey, key)
+		c.Request = c.Request.WithContext(requestctx.WithActor(c.Request.Context(), "apikey:"+key.PublicID.String()))
+		c.Next()
+	}
+}
+
+// apiKeyFrom returns the API key the request authenticated with, if any.
+func apiKeyFrom(c *gin.Context) (*apikey.APIKey, bool) {
+	value, ok := c.Get(ContextKeyAPIKey)
+	if !ok {
+		return nil, false
+	}
+	key, ok := value.(*apikey.APIKey)
+	return key, ok
+}
+
+func issueAPIKeyHandler(service *apikey.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input apikey.Input
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Issue(c.Request.Context(), input)
+		if err != nil {
+			respondAPIKeyError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, result)
+	}
+}
+
+func apiKeysHandler(service *apikey.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := service.List(c.Request.Context())
+		if err != nil {
+			respondAPIKeyError(c, err)
+			return
+		}
+		if items == nil {
+			items = []apikey.APIKey{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	}
+}
+
+func revokeAPIKeyHandler(service *apikey.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		if err := service.Revoke(c.Request.Context(), id); err != nil {
+			respondAPIKeyError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// respondAPIKeyError maps apikey package errors onto HTTP status codes.
+func respondAPIKeyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apikey.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, apikey.ErrInvalidAPIKey):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// requireScope admits API keys holding scope and rejects every other key with 403.
+// Requests made without an API key are passed to next.
+func requireScope(scope apikey.Scope, next gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := apiKeyFrom(c)
+		if !ok {
+			next(c)
+			return
+		}
+		if scope != "" && key.HasScope(scope) {
+			c.Next()
+			return
+		}
+
+		message := "api keys cannot access this route"
+		if scope != "" {
+			message = "requires api key scope " + string(scope)
+		}
+		auth.Forbidden(c, message)
+	}
+}
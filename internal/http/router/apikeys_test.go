@@ -0,0 +1,51 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+
+	"gin-mania-backend/internal/apikey"
+)
+
+func TestRequireScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	readKey := &apikey.APIKey{Scopes: pq.StringArray{string(apikey.ScopeCatalogueRead)}}
+	tests := []struct {
+		name  string
+		key   *apikey.APIKey
+		scope apikey.Scope
+		want  int
+	}{
+		{name: "key with scope", key: readKey, scope: apikey.ScopeCatalogueRead, want: http.StatusOK},
+		{name: "key without scope", key: readKey, scope: apikey.ScopeCatalogueWrite, want: http.StatusForbidden},
+		{name: "route closed to keys", key: readKey, want: http.StatusForbidden},
+		{name: "no key falls through", scope: apikey.ScopeCatalogueWrite, want: http.StatusTeapot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				if tt.key != nil {
+					c.Set(ContextKeyAPIKey, tt.key)
+				}
+			})
+			next := func(c *gin.Context) { c.AbortWithStatus(http.StatusTeapot) }
+			engine.GET("/admin/gins", requireScope(tt.scope, next), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/gins", nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/distillery"
+	"gin-mania-backend/internal/search"
+)
+
+func distilleriesHandler(service *distillery.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := distillery.ListFilter{
+			Query:   c.Query("q"),
+			Country: c.Query("country"),
+		}
+
+		if limitStr := c.Query("limit"); limitStr != "" {
+			limit, err := strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+				return
+			}
+			filter.Limit = limit
+		}
+
+		if offsetStr := c.Query("offset"); offsetStr != "" {
+			offset, err := strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+				return
+			}
+			filter.Offset = offset
+		}
+
+		items, total, err := service.List(c.Request.Context(), filter)
+		if err != nil {
+			respondDistilleryError(c, err)
+			return
+		}
+		if items == nil {
+			items = []distillery.Distillery{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"limit":  filter.Limit,
+			"offset": filter.Offset,
+			"total":  total,
+			"items":  items,
+		})
+	}
+}
+
+func distilleryDetailHandler(service *distillery.Service, searchService *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		result, err := service.Get(c.Request.Context(), id)
+		if err != nil {
+			respondDistilleryError(c, err)
+			return
+		}
+
+		gins, err := searchService.Search(c.Request.Context(), search.SearchFilter{DistilleryID: &id, Limit: search.MaxSearchLimit})
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+		if gins.Gins == nil {
+			gins.Gins = []search.Gin{}
+		}
+
+		c.JSON(http.StatusOK, struct {
+			*distillery.Distillery
+			Gins []search.Gin `json:"gins"`
+		}{result, gins.Gins})
+	}
+}
+
+func createDistilleryHandler(service *distillery.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input distillery.Input
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Create(c.Request.Context(), input)
+		if err != nil {
+			respondDistilleryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, result)
+	}
+}
+
+func updateDistilleryHandler(service *distillery.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		var input distillery.Input
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Update(c.Request.Context(), id, input)
+		if err != nil {
+			respondDistilleryError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func deleteDistilleryHandler(service *distillery.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		if err := service.Delete(c.Request.Context(), id); err != nil {
+			respondDistilleryError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// respondDistilleryError maps distillery package errors onto HTTP status codes.
+func respondDistilleryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, distillery.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, distillery.ErrDuplicateName):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, distillery.ErrInvalidPagination), errors.Is(err, distillery.ErrInvalidDistillery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,201 @@
+package router
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/importer"
+	"gin-mania-backend/internal/search"
+)
+
+const (
+	// exportFlushEvery is how many rows are buffered before flushing to the client.
+	exportFlushEvery = 100
+	// exportErrorTrailer is the HTTP trailer set when an export fails after it has started.
+	exportErrorTrailer = "X-Export-Error"
+	// exportInterrupted is reported to clients whose export failed after it started.
+	exportInterrupted = "export interrupted"
+)
+
+// exportFormat writes exported gins in one serialization.
+type exportFormat struct {
+	contentType string
+	extension   string
+	newWriter   func(w io.Writer) exportWriter
+}
+
+type exportWriter interface {
+	begin() error
+	write(gin search.Gin) error
+	// abort marks a stream that ended early, where the format allows it.
+	abort(message string) error
+	flush() error
+}
+
+var jsonlExportFormat = exportFormat{
+	contentType: "application/x-ndjson",
+	extension:   "jsonl",
+	newWriter:   func(w io.Writer) exportWriter { return &jsonlExportWriter{enc: json.NewEncoder(w)} },
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv": {
+		contentType: "text/csv; charset=utf-8",
+		extension:   "csv",
+		newWriter:   func(w io.Writer) exportWriter { return &csvExportWriter{w: csv.NewWriter(w)} },
+	},
+	"jsonl":  jsonlExportFormat,
+	"ndjson": jsonlExportFormat,
+}
+
+// csvExportWriter writes the import CSV layout so that exports can be edited and re-imported.
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (e *csvExportWriter) begin() error {
+	return e.w.Write(importer.Columns)
+}
+
+func (e *csvExportWriter) write(gin search.Gin) error {
+	return e.w.Write(importer.Record(gin))
+}
+
+// abort writes nothing: any extra row would be read as a gin, so CSV clients rely on the
+// X-Export-Error trailer instead.
+func (e *csvExportWriter) abort(string) error {
+	return nil
+}
+
+func (e *csvExportWriter) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonlExportWriter struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlExportWriter) begin() error {
+	return nil
+}
+
+func (e *jsonlExportWriter) write(gin search.Gin) error {
+	return e.enc.Encode(gin)
+}
+
+// abort ends the stream with an {"error": ...} line, which no gin line can be mistaken for.
+func (e *jsonlExportWriter) abort(message string) error {
+	return e.enc.Encode(map[string]string{"error": message})
+}
+
+func (e *jsonlExportWriter) flush() error {
+	return nil
+}
+
+// exportGinsHandler streams every gin matching the /gins filters as CSV or JSON Lines.
+// Headers are sent with the first row, so filter errors still produce a JSON error response.
+// A failure after that cannot change the 200 status: the stream is cut short, JSON Lines
+// exports end with an error line, and both formats set the X-Export-Error trailer.
+//
+// Large exports outlast the server's write timeout, which is meant for ordinary responses.
+// Instead, each flushed chunk must reach the client within writeTimeout, so a client that
+// stops reading is dropped, and exportTimeout bounds the whole export together with the
+// database connection it holds. A zero timeout disables the bound, as for http.Server.
+func exportGinsHandler(service *search.Service, writeTimeout, exportTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, ok := exportFormats[c.DefaultQuery("format", "csv")]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of csv, jsonl or ndjson"})
+			return
+		}
+
+		ctx := c.Request.Context()
+		if exportTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, exportTimeout)
+			defer cancel()
+		}
+
+		controller := http.NewResponseController(c.Writer)
+		extendDeadline := func() {
+			var deadline time.Time
+			if writeTimeout > 0 {
+				deadline = time.Now().Add(writeTimeout)
+			}
+			if err := controller.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				c.Error(fmt.Errorf("extend export write deadline: %w", err))
+			}
+		}
+		extendDeadline()
+
+		filter, err := parseSearchFilter(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		writer := format.newWriter(c.Writer)
+		started := false
+		begin := func() error {
+			started = true
+			c.Header("Trailer", exportErrorTrailer)
+			c.Header("Content-Type", format.contentType)
+			c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="gins.%s"`, format.extension))
+			c.Status(http.StatusOK)
+			return writer.begin()
+		}
+
+		rows := 0
+		err = service.Export(ctx, filter, func(gin search.Gin) error {
+			if !started {
+				if err := begin(); err != nil {
+					return err
+				}
+			}
+			if err := writer.write(gin); err != nil {
+				return err
+			}
+
+			rows++
+			if rows%exportFlushEvery == 0 {
+				if err := writer.flush(); err != nil {
+					return err
+				}
+				extendDeadline()
+				c.Writer.Flush()
+			}
+			return nil
+		})
+		if err == nil && !started {
+			err = begin()
+		}
+		if err != nil {
+			if !started {
+				respondSearchError(c, err)
+				return
+			}
+			// The response is already under way; record the error and cut the stream short.
+			c.Error(fmt.Errorf("%s: %w", exportInterrupted, err))
+			extendDeadline()
+			if err := writer.abort(exportInterrupted); err == nil {
+				_ = writer.flush()
+			}
+			c.Writer.Header().Set(exportErrorTrailer, exportInterrupted)
+			return
+		}
+
+		extendDeadline()
+		if err := writer.flush(); err != nil {
+			c.Error(err)
+		}
+	}
+}
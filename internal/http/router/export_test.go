@@ -0,0 +1,82 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/search"
+)
+
+// stalledExportRepository streams rows and then stalls until the export is cancelled. Other
+// methods are not implemented.
+type stalledExportRepository struct {
+	search.Repository
+	rows int
+}
+
+func (r *stalledExportRepository) Export(ctx context.Context, _ search.SearchFilter, each func(search.Gin) error) error {
+	for i := 0; i < r.rows; i++ {
+		if err := each(search.Gin{Name: fmt.Sprintf("Gin %d", i), Country: "Japan"}); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestExportTimeoutBoundsTheQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		rows     int
+		want     int
+		lastLine string
+	}{
+		{name: "before the first row", rows: 0, want: http.StatusInternalServerError},
+		{name: "after the first row", rows: 3, want: http.StatusOK, lastLine: `{"error":"export interrupted"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := search.NewService(&stalledExportRepository{rows: tt.rows})
+			engine := gin.New()
+			engine.GET("/gins/export", exportGinsHandler(service, time.Second, 20*time.Millisecond))
+
+			done := make(chan *httptest.ResponseRecorder)
+			go func() {
+				rec := httptest.NewRecorder()
+				engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gins/export?format=jsonl", nil))
+				done <- rec
+			}()
+
+			var rec *httptest.ResponseRecorder
+			select {
+			case rec = <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("export did not stop at its timeout")
+			}
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.lastLine == "" {
+				return
+			}
+			lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+			if len(lines) != tt.rows+1 || lines[len(lines)-1] != tt.lastLine {
+				t.Fatalf("body = %q, want %d rows and then %s", rec.Body, tt.rows, tt.lastLine)
+			}
+			if got := rec.Header().Get(exportErrorTrailer); got != exportInterrupted {
+				t.Fatalf("%s trailer = %q, want %q", exportErrorTrailer, got, exportInterrupted)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package router
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/importer"
+)
+
+// maxImportFileBytes bounds CSV uploads; MaxRows rows comfortably fit well below it.
+const maxImportFileBytes = 20 << 20
+
+// importGinsHandler queues a CSV upload sent as the "file" field of a multipart form for
+// import and responds with the background job that runs it. Passing dry_run=true reports
+// what would change without writing.
+func importGinsHandler(service *importer.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun := false
+		if dryRunStr := c.Query("dry_run"); dryRunStr != "" {
+			parsed, err := strconv.ParseBool(dryRunStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run must be a boolean"})
+				return
+			}
+			dryRun = parsed
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileBytes+(1<<20))
+		header, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file must be uploaded as multipart form field \"file\""})
+			return
+		}
+		if header.Size > maxImportFileBytes {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file could not be read"})
+			return
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file could not be read"})
+			return
+		}
+
+		job, err := service.Submit(c.Request.Context(), header.Filename, content, dryRun)
+		if err != nil {
+			respondImportError(c, err)
+			return
+		}
+
+		c.Header("Location", "/admin/jobs/"+job.PublicID.String())
+		c.JSON(http.StatusAccepted, job)
+	}
+}
+
+// respondImportError maps importer package errors onto HTTP status codes.
+func respondImportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, importer.ErrInvalidFile):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,39 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/jobs"
+)
+
+// jobDetailHandler reports the status, progress, result and last error of a background job.
+func jobDetailHandler(service *jobs.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		job, err := service.Get(c.Request.Context(), id)
+		if err != nil {
+			respondJobError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// respondJobError maps jobs package errors onto HTTP status codes.
+func respondJobError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,39 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/search"
+)
+
+func botanicalsHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		botanicals, err := service.ListBotanicals(c.Request.Context())
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+		if botanicals == nil {
+			botanicals = []search.BotanicalCount{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"items": botanicals})
+	}
+}
+
+func flavorTagsHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tags, err := service.ListFlavorTags(c.Request.Context())
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+		if tags == nil {
+			tags = []search.FlavorTagCount{}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"items": tags})
+	}
+}
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gin-mania-backend/internal/search"
+)
+
+// paginationLinks builds an RFC 8288 Link header value with next/prev relations for
+// paginated list endpoints. The next relation uses the keyset cursor unless the client
+// is explicitly paging by offset or the ordering has no cursor; prev is only available
+// for offset pagination because cursors cannot be walked backwards. It returns an empty
+// string when no relation applies.
+func paginationLinks(requestURL *url.URL, filter search.SearchFilter, result search.SearchResult) string {
+	if filter.Limit <= 0 {
+		return ""
+	}
+
+	limit := strconv.Itoa(filter.Limit)
+
+	var links []string
+	if result.HasMore {
+		if filter.Offset > 0 || result.NextCursor == "" {
+			links = append(links, formatLink(requestURL, "next", map[string]string{
+				"limit":  limit,
+				"offset": strconv.Itoa(filter.Offset + filter.Limit),
+			}))
+		} else {
+			links = append(links, formatLink(requestURL, "next", map[string]string{
+				"limit":  limit,
+				"cursor": result.NextCursor,
+			}))
+		}
+	}
+
+	if filter.Offset > 0 {
+		prev := filter.Offset - filter.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, formatLink(requestURL, "prev", map[string]string{
+			"limit":  limit,
+			"offset": strconv.Itoa(prev),
+		}))
+	}
+
+	return strings.Join(links, ", ")
+}
+
+func formatLink(requestURL *url.URL, rel string, params map[string]string) string {
+	query := requestURL.Query()
+	query.Del("offset")
+	query.Del("cursor")
+	for key, value := range params {
+		query.Set(key, value)
+	}
+
+	target := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+	return "<" + target.String() + `>; rel="` + rel + `"`
+}
@@ -0,0 +1,107 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/auth"
+	"gin-mania-backend/internal/moderation"
+	"gin-mania-backend/internal/tasting"
+)
+
+// reviewsHandler lists the moderation queue: pending tastings by default, or those with the
+// statuses given in status, optionally limited to one user's tastings.
+func reviewsHandler(service *moderation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := moderation.ListFilter{UserID: c.Query("user_id")}
+		for _, value := range parseListQuery(c, "status") {
+			status, err := tasting.ParseStatus(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of pending, approved, rejected"})
+				return
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+
+		if limitStr := c.Query("limit"); limitStr != "" {
+			limit, err := strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+				return
+			}
+			filter.Limit = limit
+		}
+
+		if offsetStr := c.Query("offset"); offsetStr != "" {
+			offset, err := strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+				return
+			}
+			filter.Offset = offset
+		}
+
+		items, total, err := service.List(c.Request.Context(), filter)
+		if err != nil {
+			respondModerationError(c, err)
+			return
+		}
+		if items == nil {
+			items = []tasting.Tasting{}
+		}
+		if filter.Limit == 0 {
+			filter.Limit = moderation.DefaultListLimit
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"limit":  filter.Limit,
+			"offset": filter.Offset,
+			"total":  total,
+			"items":  items,
+		})
+	}
+}
+
+// moderateReviewHandler approves or rejects a tasting.
+func moderateReviewHandler(service *moderation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		var decision moderation.Decision
+		if err := c.ShouldBindJSON(&decision); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Decide(c.Request.Context(), id, decision)
+		if err != nil {
+			respondModerationError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// respondModerationError maps moderation package errors onto HTTP status codes.
+func respondModerationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, moderation.ErrActorRequired):
+		auth.Unauthorized(c, err.Error())
+	case errors.Is(err, tasting.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, moderation.ErrAlreadyDecided):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, moderation.ErrInvalidPagination), errors.Is(err, moderation.ErrInvalidDecision):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -10,18 +10,38 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"gin-mania-backend/internal/apikey"
+	"gin-mania-backend/internal/auth"
 	"gin-mania-backend/internal/config"
+	"gin-mania-backend/internal/distillery"
+	"gin-mania-backend/internal/importer"
+	"gin-mania-backend/internal/jobs"
+	"gin-mania-backend/internal/moderation"
 	"gin-mania-backend/internal/search"
+	"gin-mania-backend/internal/tasting"
+	"gin-mania-backend/internal/user"
+	"gin-mania-backend/pkg/requestctx"
 )
 
 const (
 	ContextKeyRequestID = "request_id"
 	requestIDHeader     = "X-Request-ID"
+	actorHeader         = "X-Actor"
 )
 
 // Dependencies aggregates external services required by the router.
 type Dependencies struct {
-	SearchService *search.Service
+	SearchService     *search.Service
+	DistilleryService *distillery.Service
+	ImportService     *importer.Service
+	JobService        *jobs.Service
+	TastingService    *tasting.Service
+	ModerationService *moderation.Service
+	UserService       *user.Service
+	APIKeyService     *apikey.Service
+	// Verifier authenticates bearer tokens. It may only be nil when the configuration
+	// disables authentication; otherwise every protected route responds 401.
+	Verifier *auth.Verifier
 }
 
 var (
@@ -31,6 +51,20 @@ var (
 	ErrMissingLogger = errors.New("router logger is required")
 	// ErrMissingSearchService indicates the search service dependency was missing.
 	ErrMissingSearchService = errors.New("search service is required")
+	// ErrMissingDistilleryService indicates the distillery service dependency was missing.
+	ErrMissingDistilleryService = errors.New("distillery service is required")
+	// ErrMissingImportService indicates the import service dependency was missing.
+	ErrMissingImportService = errors.New("import service is required")
+	// ErrMissingJobService indicates the job service dependency was missing.
+	ErrMissingJobService = errors.New("job service is required")
+	// ErrMissingTastingService indicates the tasting service dependency was missing.
+	ErrMissingTastingService = errors.New("tasting service is required")
+	// ErrMissingModerationService indicates the moderation service dependency was missing.
+	ErrMissingModerationService = errors.New("moderation service is required")
+	// ErrMissingUserService indicates the user service dependency was missing.
+	ErrMissingUserService = errors.New("user service is required")
+	// ErrMissingAPIKeyService indicates the API key service dependency was missing.
+	ErrMissingAPIKeyService = errors.New("api key service is required")
 )
 
 // New constructs a gin.Engine with shared middleware and registered routes.
@@ -44,6 +78,27 @@ func New(cfg *config.Config, logger *zap.Logger, deps Dependencies) (*gin.Engine
 	if deps.SearchService == nil {
 		return nil, ErrMissingSearchService
 	}
+	if deps.DistilleryService == nil {
+		return nil, ErrMissingDistilleryService
+	}
+	if deps.ImportService == nil {
+		return nil, ErrMissingImportService
+	}
+	if deps.JobService == nil {
+		return nil, ErrMissingJobService
+	}
+	if deps.TastingService == nil {
+		return nil, ErrMissingTastingService
+	}
+	if deps.ModerationService == nil {
+		return nil, ErrMissingModerationService
+	}
+	if deps.UserService == nil {
+		return nil, ErrMissingUserService
+	}
+	if deps.APIKeyService == nil {
+		return nil, ErrMissingAPIKeyService
+	}
 
 	gin.SetMode(cfg.Server.GinMode)
 
@@ -52,8 +107,15 @@ func New(cfg *config.Config, logger *zap.Logger, deps Dependencies) (*gin.Engine
 	engine.Use(requestIDMiddleware())
 	engine.Use(loggingMiddleware(logger))
 	engine.Use(corsMiddleware(cfg.Server.AllowedOrigins))
+	engine.Use(apiKeyMiddleware(deps.APIKeyService))
+	if deps.Verifier != nil {
+		engine.Use(auth.Middleware(deps.Verifier))
+		engine.Use(provisionUserMiddleware(deps.UserService))
+	} else if cfg.Auth.TrustActorHeader {
+		engine.Use(actorHeaderMiddleware(deps.UserService))
+	}
 
-	registerRoutes(engine, deps)
+	registerRoutes(engine, cfg, deps)
 
 	return engine, nil
 }
@@ -66,12 +128,42 @@ func requestIDMiddleware() gin.HandlerFunc {
 		}
 
 		c.Set(ContextKeyRequestID, requestID)
+		c.Request = c.Request.WithContext(requestctx.WithRequestID(c.Request.Context(), requestID))
 		c.Writer.Header().Set(requestIDHeader, requestID)
 
 		c.Next()
 	}
 }
 
+// actorHeaderMiddleware takes the caller's identity from the X-Actor header without
+// verifying it and provisions a matching guest user, as tokens do. It is only installed while
+// authentication is disabled outside production, so that local writes are still attributed
+// to someone.
+func actorHeaderMiddleware(service *user.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := strings.TrimSpace(c.GetHeader(actorHeader))
+		if actor == "" || requestctx.Actor(c.Request.Context()) != "" {
+			c.Next()
+			return
+		}
+
+		current, err := service.Provision(c.Request.Context(), user.Identity{Subject: actor})
+		if err != nil {
+			if errors.Is(err, user.ErrInvalidIdentity) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.Error(err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "user could not be provisioned"})
+			return
+		}
+
+		c.Set(ContextKeyUser, current)
+		c.Request = c.Request.WithContext(requestctx.WithActor(c.Request.Context(), current.AuthSubject))
+		c.Next()
+	}
+}
+
 func loggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -117,10 +209,11 @@ func corsMiddleware(allowedOrigins []string) gin.HandlerFunc {
 
 		c.Header("Vary", "Origin")
 		c.Header("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
+		c.Header("Access-Control-Expose-Headers", "Link,"+requestIDHeader)
 
 		requestHeaders := c.GetHeader("Access-Control-Request-Headers")
 		if requestHeaders == "" {
-			requestHeaders = "Authorization,Content-Type"
+			requestHeaders = "Authorization,Content-Type," + apiKeyHeader
 		}
 		c.Header("Access-Control-Allow-Headers", requestHeaders)
 
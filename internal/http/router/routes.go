@@ -2,17 +2,82 @@ package router
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
+	"gin-mania-backend/internal/apikey"
+	"gin-mania-backend/internal/auth"
+	"gin-mania-backend/internal/config"
 	"gin-mania-backend/internal/search"
 )
 
-func registerRoutes(engine *gin.Engine, deps Dependencies) {
+// registerRoutes groups routes by the access they require. Public routes are open to
+// everyone, though callers who present a token are still identified; tasting routes need a
+// member; admin routes need an admin, or for catalogue management an API key with the
+// matching scope.
+func registerRoutes(engine *gin.Engine, cfg *config.Config, deps Dependencies) {
+	// requireAccess admits API keys holding scope and users holding one of roles. An empty
+	// scope keeps API keys out of the route.
+	requireAccess := func(scope apikey.Scope, roles ...string) gin.HandlerFunc {
+		if cfg.Auth.Disabled {
+			// Without authentication there are no roles to check; see config.AuthConfig.Disabled.
+			return requireScope(scope, func(c *gin.Context) { c.Next() })
+		}
+		// Without a verifier no caller carries claims, so a misconfigured server fails closed.
+		return requireScope(scope, auth.RequireRole(roles...))
+	}
+
 	engine.GET("/healthz", healthHandler)
 	engine.GET("/gins", ginsHandler(deps.SearchService))
+	engine.GET("/gins/suggest", suggestHandler(deps.SearchService))
+	engine.GET("/gins/export", exportGinsHandler(deps.SearchService, cfg.Server.WriteTimeout, cfg.Server.ExportTimeout))
+	engine.GET("/gins/:id", ginDetailHandler(deps.SearchService))
+
+	engine.GET("/distilleries", distilleriesHandler(deps.DistilleryService))
+	engine.GET("/distilleries/:id", distilleryDetailHandler(deps.DistilleryService, deps.SearchService))
+
+	engine.GET("/me", meHandler)
+
+	meta := engine.Group("/meta")
+	meta.GET("/botanicals", botanicalsHandler(deps.SearchService))
+	meta.GET("/flavor-tags", flavorTagsHandler(deps.SearchService))
+
+	tastings := engine.Group("/tastings", requireAccess("", auth.RoleMember, auth.RoleAdmin))
+	tastings.GET("", tastingsHandler(deps.TastingService))
+	tastings.POST("", createTastingHandler(deps.TastingService))
+	tastings.PATCH("/:id", patchTastingHandler(deps.TastingService))
+
+	catalogueRead := engine.Group("/admin", requireAccess(apikey.ScopeCatalogueRead, auth.RoleAdmin))
+	catalogueRead.GET("/gins", adminGinsHandler(deps.SearchService))
+	catalogueRead.GET("/gins/deleted", deletedGinsHandler(deps.SearchService))
+	catalogueRead.GET("/gins/:id", adminGinDetailHandler(deps.SearchService))
+	catalogueRead.GET("/gins/:id/history", ginHistoryHandler(deps.SearchService))
+	catalogueRead.GET("/jobs/:id", jobDetailHandler(deps.JobService))
+
+	catalogueWrite := engine.Group("/admin", requireAccess(apikey.ScopeCatalogueWrite, auth.RoleAdmin))
+	catalogueWrite.POST("/gins/import", importGinsHandler(deps.ImportService))
+	catalogueWrite.POST("/gins", createGinHandler(deps.SearchService))
+	catalogueWrite.PUT("/gins/:id", updateGinHandler(deps.SearchService))
+	catalogueWrite.PATCH("/gins/:id", patchGinHandler(deps.SearchService))
+	catalogueWrite.DELETE("/gins/:id", deleteGinHandler(deps.SearchService))
+	catalogueWrite.POST("/gins/:id/archive", archiveGinHandler(deps.SearchService))
+	catalogueWrite.POST("/gins/:id/restore", restoreGinHandler(deps.SearchService))
+	catalogueWrite.POST("/gins/:id/revert", revertGinHandler(deps.SearchService))
+	catalogueWrite.POST("/distilleries", createDistilleryHandler(deps.DistilleryService))
+	catalogueWrite.PUT("/distilleries/:id", updateDistilleryHandler(deps.DistilleryService))
+	catalogueWrite.DELETE("/distilleries/:id", deleteDistilleryHandler(deps.DistilleryService))
+
+	admin := engine.Group("/admin", requireAccess("", auth.RoleAdmin))
+	admin.GET("/reviews", reviewsHandler(deps.ModerationService))
+	admin.PATCH("/reviews/:id", moderateReviewHandler(deps.ModerationService))
+	admin.POST("/api-keys", issueAPIKeyHandler(deps.APIKeyService))
+	admin.GET("/api-keys", apiKeysHandler(deps.APIKeyService))
+	admin.DELETE("/api-keys/:id", revokeAPIKeyHandler(deps.APIKeyService))
 }
 
 func healthHandler(c *gin.Context) {
@@ -27,36 +92,149 @@ func ginsHandler(service *search.Service) gin.HandlerFunc {
 			return
 		}
 
-		results, err := service.Search(c.Request.Context(), filter)
-		if err != nil {
-			status := http.StatusInternalServerError
-			if errors.Is(err, search.ErrInvalidPagination) {
-				status = http.StatusBadRequest
+		respondSearch(c, service, filter)
+	}
+}
+
+// respondSearch runs filter and writes the paginated search response shared by the public
+// and administrative listings.
+func respondSearch(c *gin.Context, service *search.Service, filter search.SearchFilter) {
+	if filter.Limit == 0 {
+		filter.Limit = search.DefaultSearchLimit
+	}
+
+	result, err := service.Search(c.Request.Context(), filter)
+	if err != nil {
+		respondSearchError(c, err)
+		return
+	}
+
+	if link := paginationLinks(c.Request.URL, filter, result); link != "" {
+		c.Header("Link", link)
+	}
+
+	response := gin.H{
+		"query":       filter.Query,
+		"limit":       filter.Limit,
+		"offset":      filter.Offset,
+		"total":       result.Total,
+		"sort":        filter.Sort,
+		"has_more":    result.HasMore,
+		"next_cursor": result.NextCursor,
+		"results":     result.Gins,
+	}
+	if len(result.Suggestions) > 0 {
+		response["suggestions"] = result.Suggestions
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func suggestHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := search.DefaultSuggestLimit
+		if limitStr := c.Query("limit"); limitStr != "" {
+			parsed, err := strconv.Atoi(limitStr)
+			if err != nil || parsed < 1 || parsed > search.MaxSuggestLimit {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be an integer between 1 and %d", search.MaxSuggestLimit)})
+				return
 			}
+			limit = parsed
+		}
 
-			c.Error(err)
-			c.JSON(status, gin.H{"error": err.Error()})
+		prefix := c.Query("q")
+		suggestions, err := service.Suggest(c.Request.Context(), prefix, limit)
+		if err != nil {
+			respondSearchError(c, err)
 			return
 		}
+		if suggestions == nil {
+			suggestions = []search.Suggestion{}
+		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"query":   filter.Query,
-			"limit":   filter.Limit,
-			"offset":  filter.Offset,
-			"results": results,
+			"query":       prefix,
+			"suggestions": suggestions,
 		})
 	}
 }
 
+func ginDetailHandler(service *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		result, err := service.GetPublished(c.Request.Context(), id)
+		if err != nil {
+			respondSearchError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
 func parseSearchFilter(c *gin.Context) (search.SearchFilter, error) {
 	filter := search.SearchFilter{
-		Query: c.Query("q"),
+		Query:      c.Query("q"),
+		Name:       c.Query("name"),
+		Distillery: c.Query("distillery"),
+		Country:    c.Query("country"),
+		Region:     c.Query("region"),
+		Botanicals: parseListQuery(c, "botanical"),
+		FlavorTags: parseListQuery(c, "flavor_tag"),
+		Cursor:     strings.TrimSpace(c.Query("cursor")),
+	}
+
+	if distilleryIDStr := c.Query("distillery_id"); distilleryIDStr != "" {
+		distilleryID, err := uuid.Parse(distilleryIDStr)
+		if err != nil {
+			return search.SearchFilter{}, errors.New("distillery_id must be a valid UUID")
+		}
+		filter.DistilleryID = &distilleryID
+	}
+
+	switch match := search.BotanicalMatch(c.DefaultQuery("botanical_match", string(search.BotanicalMatchAny))); match {
+	case search.BotanicalMatchAny, search.BotanicalMatchAll:
+		filter.BotanicalMatch = match
+	default:
+		return search.SearchFilter{}, errors.New("botanical_match must be either any or all")
+	}
+
+	if fuzzyStr := c.Query("fuzzy"); fuzzyStr != "" {
+		fuzzy, err := strconv.ParseBool(fuzzyStr)
+		if err != nil {
+			return search.SearchFilter{}, errors.New("fuzzy must be a boolean")
+		}
+		filter.Fuzzy = fuzzy
+	}
+
+	if abvMinStr := c.Query("abv_min"); abvMinStr != "" {
+		abvMin, err := strconv.ParseFloat(abvMinStr, 64)
+		if err != nil || abvMin < 0 || abvMin > 100 {
+			return search.SearchFilter{}, errors.New("abv_min must be a number between 0 and 100")
+		}
+		filter.ABVMin = &abvMin
+	}
+
+	if abvMaxStr := c.Query("abv_max"); abvMaxStr != "" {
+		abvMax, err := strconv.ParseFloat(abvMaxStr, 64)
+		if err != nil || abvMax < 0 || abvMax > 100 {
+			return search.SearchFilter{}, errors.New("abv_max must be a number between 0 and 100")
+		}
+		filter.ABVMax = &abvMax
+	}
+
+	if filter.ABVMin != nil && filter.ABVMax != nil && *filter.ABVMin > *filter.ABVMax {
+		return search.SearchFilter{}, errors.New("abv_min must not exceed abv_max")
 	}
 
 	if limitStr := c.Query("limit"); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
-		if err != nil || limit < 0 {
-			return search.SearchFilter{}, errors.New("limit must be a non-negative integer")
+		if err != nil || limit < 0 || limit > search.MaxSearchLimit {
+			return search.SearchFilter{}, fmt.Errorf("limit must be an integer between 0 and %d", search.MaxSearchLimit)
 		}
 		filter.Limit = limit
 	}
@@ -69,5 +247,60 @@ func parseSearchFilter(c *gin.Context) (search.SearchFilter, error) {
 		filter.Offset = offset
 	}
 
+	if sortParam := strings.TrimSpace(c.Query("sort")); sortParam != "" {
+		sort, err := search.ParseSortOrder(sortParam)
+		if err != nil {
+			return search.SearchFilter{}, errors.New("sort must be one of name, -name, country, -country, created_at, -created_at, updated_at, -updated_at, relevance")
+		}
+		if sort == search.SortRelevance && strings.TrimSpace(filter.Query) == "" {
+			return search.SearchFilter{}, errors.New("sort=relevance requires q")
+		}
+		filter.Sort = sort
+	}
+
+	if filter.Cursor != "" && filter.Offset > 0 {
+		return search.SearchFilter{}, errors.New("cursor and offset cannot be combined")
+	}
+
 	return filter, nil
 }
+
+// parseListQuery collects a multi-valued query parameter, accepting both repeated keys
+// (?botanical=a&botanical=b) and comma-separated values (?botanical=a,b).
+func parseListQuery(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, part := range strings.Split(raw, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				values = append(values, trimmed)
+			}
+		}
+	}
+	return values
+}
+
+// parseID reads the :id path parameter, writing a 400 response when it is not a UUID.
+func parseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be a valid UUID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondSearchError maps search package errors onto HTTP status codes.
+func respondSearchError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, search.ErrNotFound), errors.Is(err, search.ErrRevisionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, search.ErrInvalidStatusTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, search.ErrInvalidPagination), errors.Is(err, search.ErrInvalidFilter),
+		errors.Is(err, search.ErrInvalidGin):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
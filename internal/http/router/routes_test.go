@@ -0,0 +1,44 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/config"
+)
+
+func TestAdminRoutesFailClosedWithoutVerifier(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		auth config.AuthConfig
+		want int
+	}{
+		// Auth0 is expected but no verifier was wired up: nobody can be authenticated.
+		{name: "misconfigured", auth: config.AuthConfig{Enabled: true}, want: http.StatusUnauthorized},
+		{name: "not disabled", auth: config.AuthConfig{}, want: http.StatusUnauthorized},
+		// Explicitly disabled authentication lets the request through to the handler, which
+		// rejects the malformed job id.
+		{name: "disabled", auth: config.AuthConfig{Disabled: true}, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			registerRoutes(engine, &config.Config{Auth: tt.auth}, Dependencies{})
+
+			for _, path := range []string{"/admin/jobs/not-a-uuid", "/admin/gins/not-a-uuid/history"} {
+				rec := httptest.NewRecorder()
+				engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+				if rec.Code != tt.want {
+					t.Fatalf("GET %s: status = %d, want %d: %s", path, rec.Code, tt.want, rec.Body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/auth"
+	"gin-mania-backend/internal/tasting"
+	"gin-mania-backend/pkg/requestctx"
+)
+
+// tastingsHandler lists the caller's tasting logs, optionally filtered by status.
+func tastingsHandler(service *tasting.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireUser(c)
+		if !ok {
+			return
+		}
+
+		var filter tasting.ListFilter
+		for _, value := range parseListQuery(c, "status") {
+			status, err := tasting.ParseStatus(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of pending, approved, rejected"})
+				return
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+
+		if limitStr := c.Query("limit"); limitStr != "" {
+			limit, err := strconv.Atoi(limitStr)
+			if err != nil || limit < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+				return
+			}
+			filter.Limit = limit
+		}
+
+		if offsetStr := c.Query("offset"); offsetStr != "" {
+			offset, err := strconv.Atoi(offsetStr)
+			if err != nil || offset < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+				return
+			}
+			filter.Offset = offset
+		}
+
+		items, total, err := service.List(c.Request.Context(), userID, filter)
+		if err != nil {
+			respondTastingError(c, err)
+			return
+		}
+		if items == nil {
+			items = []tasting.Tasting{}
+		}
+		if filter.Limit == 0 {
+			filter.Limit = tasting.DefaultListLimit
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"limit":  filter.Limit,
+			"offset": filter.Offset,
+			"total":  total,
+			"items":  items,
+		})
+	}
+}
+
+func createTastingHandler(service *tasting.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireUser(c)
+		if !ok {
+			return
+		}
+
+		var input tasting.Input
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Create(c.Request.Context(), userID, input)
+		if err != nil {
+			respondTastingError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, result)
+	}
+}
+
+func patchTastingHandler(service *tasting.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireUser(c)
+		if !ok {
+			return
+		}
+
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+
+		var patch tasting.Patch
+		if err := c.ShouldBindJSON(&patch); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		result, err := service.Update(c.Request.Context(), userID, id, patch)
+		if err != nil {
+			respondTastingError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// requireUser returns the authenticated caller, writing a 401 response when there is none.
+// The caller comes from a verified token, or from the X-Actor header while authentication is
+// disabled and that header is trusted.
+func requireUser(c *gin.Context) (string, bool) {
+	userID := requestctx.Actor(c.Request.Context())
+	if userID == "" {
+		auth.Unauthorized(c, "authentication required")
+		return "", false
+	}
+	return userID, true
+}
+
+// respondTastingError maps tasting package errors onto HTTP status codes.
+func respondTastingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tasting.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, tasting.ErrInvalidPagination), errors.Is(err, tasting.ErrInvalidTasting):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
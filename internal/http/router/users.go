@@ -0,0 +1,52 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-mania-backend/internal/auth"
+	"gin-mania-backend/internal/user"
+)
+
+// ContextKeyUser is the Gin context key holding the authenticated caller's *user.User.
+const ContextKeyUser = "user"
+
+// provisionUserMiddleware creates or updates the user behind a verified token, so that
+// every authenticated request has a matching users row. Anonymous requests pass through.
+func provisionUserMiddleware(service *user.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := auth.ClaimsFrom(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		current, err := service.Provision(c.Request.Context(), user.Identity{
+			Subject:     claims.Subject,
+			Email:       claims.Email,
+			DisplayName: claims.Name,
+			Roles:       claims.Roles,
+		})
+		if err != nil {
+			c.Error(err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "user could not be provisioned"})
+			return
+		}
+
+		c.Set(ContextKeyUser, current)
+		c.Next()
+	}
+}
+
+// meHandler returns the authenticated caller's profile.
+func meHandler(c *gin.Context) {
+	value, ok := c.Get(ContextKeyUser)
+	current, _ := value.(*user.User)
+	if !ok || current == nil {
+		auth.Unauthorized(c, "authentication required")
+		return
+	}
+
+	c.JSON(http.StatusOK, current)
+}
@@ -0,0 +1,211 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gin-mania-backend/internal/search"
+)
+
+const (
+	// MaxRows bounds the number of data rows accepted in a single upload.
+	MaxRows = 20000
+	// listSeparator joins multi-valued cells such as botanicals and flavor tags.
+	listSeparator = ";"
+)
+
+// Columns is the CSV layout shared by imports and exports. The id and status columns are
+// optional on import; every other column must be present so that a row fully describes a gin.
+var Columns = []string{
+	"id", "name", "distillery", "country", "region", "abv", "botanicals",
+	"flavor_tags", "description", "tasting_notes", "image_url", "status",
+}
+
+var optionalColumns = map[string]bool{"id": true, "status": true}
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// Record formats a gin as a CSV row in Columns order.
+func Record(gin search.Gin) []string {
+	abv := ""
+	if gin.ABV != nil {
+		abv = strconv.FormatFloat(*gin.ABV, 'f', -1, 64)
+	}
+
+	flavorTags := make([]string, len(gin.FlavorTags))
+	for i, tag := range gin.FlavorTags {
+		flavorTags[i] = tag.Code
+	}
+
+	return []string{
+		gin.PublicID.String(),
+		gin.Name,
+		gin.Distillery,
+		gin.Country,
+		gin.Region,
+		abv,
+		strings.Join(gin.Botanicals, listSeparator),
+		strings.Join(flavorTags, listSeparator),
+		gin.Description,
+		gin.TastingNotes,
+		gin.ImageURL,
+		string(gin.Status),
+	}
+}
+
+// Parse reads an uploaded CSV file. Problems with the file as a whole, such as invalid
+// UTF-8 or headers, are returned as an ErrInvalidFile error. Rows that cannot be parsed are
+// returned as failed outcomes alongside the items parsed from the remaining rows.
+func Parse(content []byte) ([]search.ImportItem, []search.ImportOutcome, error) {
+	content = bytes.TrimPrefix(content, utf8BOM)
+	if !utf8.Valid(content) {
+		return nil, nil, fmt.Errorf("%w: file is not valid UTF-8", ErrInvalidFile)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("%w: file is empty", ErrInvalidFile)
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	index, err := parseHeader(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []search.ImportItem
+	var failures []search.ImportOutcome
+	for rows := 0; ; rows++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if rows == MaxRows {
+			return nil, nil, fmt.Errorf("%w: file has more than %d rows", ErrInvalidFile, MaxRows)
+		}
+
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount):
+			failures = append(failures, failedRow(parseErr.StartLine, fmt.Sprintf("expected %d fields, got %d", len(header), len(record))))
+			continue
+		case err != nil:
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		item, err := parseRecord(line, record, index)
+		if err != nil {
+			failures = append(failures, failedRow(line, err.Error()))
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, failures, nil
+}
+
+// parseHeader maps column names to their position, rejecting unknown, duplicate and
+// missing columns.
+func parseHeader(header []string) (map[string]int, error) {
+	known := make(map[string]bool, len(Columns))
+	for _, column := range Columns {
+		known[column] = true
+	}
+
+	index := make(map[string]int, len(header))
+	var problems []string
+	for i, raw := range header {
+		column := strings.ToLower(strings.TrimSpace(raw))
+		switch {
+		case !known[column]:
+			problems = append(problems, fmt.Sprintf("unknown column %q", raw))
+		case hasColumn(index, column):
+			problems = append(problems, fmt.Sprintf("duplicate column %q", column))
+		default:
+			index[column] = i
+		}
+	}
+
+	for _, column := range Columns {
+		if !optionalColumns[column] && !hasColumn(index, column) {
+			problems = append(problems, fmt.Sprintf("missing column %q", column))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFile, strings.Join(problems, "; "))
+	}
+	return index, nil
+}
+
+func hasColumn(index map[string]int, column string) bool {
+	_, ok := index[column]
+	return ok
+}
+
+func parseRecord(line int, record []string, index map[string]int) (search.ImportItem, error) {
+	field := func(column string) string {
+		if i, ok := index[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	item := search.ImportItem{
+		Line:       line,
+		Distillery: field("distillery"),
+		Input: search.GinInput{
+			Name:         field("name"),
+			Country:      field("country"),
+			Region:       field("region"),
+			Botanicals:   splitList(field("botanicals")),
+			FlavorTags:   splitList(field("flavor_tags")),
+			Description:  field("description"),
+			TastingNotes: field("tasting_notes"),
+			ImageURL:     field("image_url"),
+			Status:       search.Status(field("status")),
+		},
+	}
+
+	if value := field("id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return search.ImportItem{}, errors.New("id must be a valid UUID")
+		}
+		item.ID = &id
+	}
+
+	if value := field("abv"); value != "" {
+		abv, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return search.ImportItem{}, errors.New("abv must be a number")
+		}
+		item.Input.ABV = &abv
+	}
+
+	return item, nil
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, listSeparator)
+}
+
+func failedRow(line int, message string) search.ImportOutcome {
+	return search.ImportOutcome{Line: line, Action: search.ImportFailed, Error: message}
+}
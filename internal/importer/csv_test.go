@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gin-mania-backend/internal/search"
+)
+
+const header = "name,distillery,country,region,abv,botanicals,flavor_tags,description,tasting_notes,image_url\n"
+
+func TestParseRows(t *testing.T) {
+	content := "\xef\xbb\xbf" + header +
+		"Monkey 47,Black Forest Distillers,Germany,Baden-Württemberg,47,juniper;lingonberry,herbal,,,\n" +
+		"Broken,,Japan,,strong,,,,,\n" +
+		"Too,few,fields\n" +
+		"Roku,Suntory,Japan,,43,sakura; yuzu ,,,,\n"
+
+	items, failures, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	first := items[0]
+	if first.Line != 2 || first.Input.Name != "Monkey 47" || first.Distillery != "Black Forest Distillers" {
+		t.Errorf("first item = %+v", first)
+	}
+	if first.Input.ABV == nil || *first.Input.ABV != 47 {
+		t.Errorf("first item abv = %v, want 47", first.Input.ABV)
+	}
+	if got := strings.Join(first.Input.Botanicals, "|"); got != "juniper|lingonberry" {
+		t.Errorf("first item botanicals = %q", got)
+	}
+	if items[1].Line != 5 || items[1].Input.Name != "Roku" {
+		t.Errorf("second item = %+v", items[1])
+	}
+
+	if len(failures) != 2 {
+		t.Fatalf("got %d failures, want 2: %+v", len(failures), failures)
+	}
+	for i, want := range []struct {
+		line    int
+		message string
+	}{
+		{line: 3, message: "abv must be a number"},
+		{line: 4, message: "expected 10 fields, got 3"},
+	} {
+		failure := failures[i]
+		if failure.Line != want.line || failure.Action != search.ImportFailed || failure.Error != want.message {
+			t.Errorf("failure %d = %+v, want line %d %q", i, failure, want.line, want.message)
+		}
+	}
+}
+
+func TestParseOptionalColumns(t *testing.T) {
+	content := "id,status," + header +
+		"b9a3c3f4-5a43-4a4e-9a55-3a8f0d4e3f01,published,Roku,,Japan,,,,,,,\n" +
+		"not-a-uuid,,Sipsmith,,UK,,,,,,,\n"
+
+	items, failures, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if len(items) != 1 || items[0].ID == nil || items[0].Input.Status != search.StatusPublished {
+		t.Fatalf("items = %+v", items)
+	}
+	if len(failures) != 1 || failures[0].Error != "id must be a valid UUID" {
+		t.Fatalf("failures = %+v", failures)
+	}
+}
+
+func TestParseRejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "empty", content: "", want: "file is empty"},
+		{name: "invalid UTF-8", content: header + "\xff\xfe,,,,,,,,,\n", want: "not valid UTF-8"},
+		{name: "unknown column", content: "colour," + header, want: `unknown column "colour"`},
+		{name: "duplicate column", content: "name," + header, want: `duplicate column "name"`},
+		{name: "missing column", content: "name,country\n", want: `missing column "distillery"`},
+		{name: "too many rows", content: header + strings.Repeat("Gin,,UK,,,,,,,\n", MaxRows+1), want: "more than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Parse([]byte(tt.content))
+			if !errors.Is(err, ErrInvalidFile) {
+				t.Fatalf("error = %v, want ErrInvalidFile", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %q, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordMatchesColumns(t *testing.T) {
+	abv := 41.6
+	record := Record(search.Gin{
+		Name:       "Hendrick's",
+		Botanicals: []string{"juniper", "rose", "cucumber"},
+		ABV:        &abv,
+		Status:     search.StatusPublished,
+	})
+	if len(record) != len(Columns) {
+		t.Fatalf("record has %d fields, want %d", len(record), len(Columns))
+	}
+
+	items, failures, err := Parse([]byte(strings.Join(Columns, ",") + "\n" + strings.Join(record, ",") + "\n"))
+	if err != nil || len(failures) != 0 || len(items) != 1 {
+		t.Fatalf("re-parse: items=%+v failures=%+v err=%v", items, failures, err)
+	}
+	if got := items[0].Input; got.Name != "Hendrick's" || *got.ABV != abv || len(got.Botanicals) != 3 {
+		t.Fatalf("re-parsed input = %+v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gin-mania-backend/internal/search"
+)
+
+// JobStatus is the processing state of an import job.
+type JobStatus string
+
+const (
+	JobQueued     JobStatus = "queued"
+	JobProcessing JobStatus = "processing"
+	JobCompleted  JobStatus = "completed"
+	JobFailed     JobStatus = "failed"
+)
+
+// Job records one CSV upload and the outcome of importing it.
+type Job struct {
+	ID            uint      `json:"-" gorm:"column:id;primaryKey"`
+	PublicID      uuid.UUID `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	FileName      string    `json:"file_name" gorm:"column:file_name;type:varchar(255);not null"`
+	UploadedBy    string    `json:"uploaded_by" gorm:"column:uploaded_by;type:varchar(255);not null"`
+	Status        JobStatus `json:"status" gorm:"column:status;type:varchar(16);not null"`
+	DryRun        bool      `json:"dry_run" gorm:"column:dry_run;not null"`
+	TotalRows     int       `json:"total_rows" gorm:"column:total_rows;not null"`
+	SucceededRows int       `json:"succeeded_rows" gorm:"column:succeeded_rows;not null"`
+	FailedRows    int       `json:"failed_rows" gorm:"column:failed_rows;not null"`
+	Summary       Summary   `json:"summary" gorm:"column:summary;type:jsonb;not null"`
+	// Content holds the uploaded file until the import has run.
+	Content   []byte    `json:"-" gorm:"column:content;type:bytea"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// TableName specifies the PostgreSQL table name for import jobs.
+func (Job) TableName() string {
+	return "csv_import_jobs"
+}
+
+// Summary breaks an import down by row outcome and lists every row with what happened to it.
+type Summary struct {
+	Created   int                    `json:"created"`
+	Updated   int                    `json:"updated"`
+	Unchanged int                    `json:"unchanged"`
+	Failed    int                    `json:"failed"`
+	Rows      []search.ImportOutcome `json:"rows"`
+	// Error explains why a job failed as a whole rather than row by row.
+	Error string `json:"error,omitempty"`
+}
+
+// Value implements driver.Valuer.
+func (s Summary) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner.
+func (s *Summary) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported summary value %T", value)
+	}
+	return json.Unmarshal(raw, s)
+}
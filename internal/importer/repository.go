@@ -0,0 +1,53 @@
+package importer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines access methods to import job storage.
+type Repository interface {
+	Create(ctx context.Context, job *Job) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+	Update(ctx context.Context, job *Job) error
+}
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+// NewRepository constructs a Repository backed by GORM.
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) Create(ctx context.Context, job *Job) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *gormRepository) GetByID(ctx context.Context, id uuid.UUID) (*Job, error) {
+	var job Job
+
+	err := r.db.WithContext(ctx).Where("public_id = ?", id).Take(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Update writes the job's status and counts. The uploaded content is never rewritten; it is
+// only cleared, once the job no longer holds it.
+func (r *gormRepository) Update(ctx context.Context, job *Job) error {
+	columns := []string{"status", "succeeded_rows", "failed_rows", "summary", "updated_at"}
+	if job.Content == nil {
+		columns = append(columns, "content")
+	}
+	return r.db.WithContext(ctx).Model(job).Select(columns).Updates(job).Error
+}
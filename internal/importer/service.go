@@ -0,0 +1,222 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"gin-mania-backend/internal/jobs"
+	"gin-mania-backend/internal/search"
+	"gin-mania-backend/pkg/requestctx"
+)
+
+const (
+	// JobKind identifies import jobs in the background job queue.
+	JobKind = "gin_import"
+	// maxFileNameLength matches the csv_import_jobs.file_name column.
+	maxFileNameLength = 255
+)
+
+var (
+	// ErrRepositoryNotConfigured indicates that the service was constructed without its dependencies.
+	ErrRepositoryNotConfigured = errors.New("import repository not configured")
+	// ErrInvalidFile is returned when an uploaded file cannot be imported at all.
+	ErrInvalidFile = errors.New("invalid import file")
+	// ErrNotFound is returned when the import referenced by a background job does not exist.
+	ErrNotFound = errors.New("import not found")
+)
+
+// Service imports gins from CSV files and records each upload as a job.
+type Service struct {
+	repo Repository
+	gins *search.Service
+	jobs *jobs.Service
+}
+
+// NewService constructs a new Service that writes gins through the provided search service
+// and runs imports on the background job queue.
+func NewService(repo Repository, gins *search.Service, jobQueue *jobs.Service) *Service {
+	return &Service{repo: repo, gins: gins, jobs: jobQueue}
+}
+
+// jobPayload is the background job payload of an import.
+type jobPayload struct {
+	ImportID uuid.UUID `json:"import_id"`
+}
+
+// Submit validates a CSV file and queues it for import, returning the background job that
+// runs it. Problems with the file as a whole are reported immediately; rows are validated
+// and imported by the job.
+func (s *Service) Submit(ctx context.Context, fileName string, content []byte, dryRun bool) (*jobs.Job, error) {
+	if s.repo == nil || s.gins == nil || s.jobs == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	items, failures, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &Job{
+		PublicID:   uuid.New(),
+		FileName:   truncate(fileName, maxFileNameLength),
+		UploadedBy: requestctx.Actor(ctx),
+		Status:     JobQueued,
+		DryRun:     dryRun,
+		TotalRows:  len(items) + len(failures),
+		Summary:    Summary{Rows: []search.ImportOutcome{}},
+		Content:    content,
+	}
+	if err := s.repo.Create(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobs.Enqueue(ctx, JobKind, jobPayload{ImportID: upload.PublicID})
+	if err != nil {
+		upload.Status = JobFailed
+		upload.Summary.Error = "import could not be queued"
+		upload.Content = nil
+		if updateErr := s.repo.Update(ctx, upload); updateErr != nil {
+			return nil, fmt.Errorf("%w (marking import failed: %v)", err, updateErr)
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// Process is the background job handler for imports. Rows are created or updated within a
+// single transaction, so an interrupted run leaves no partial import behind and is simply
+// run again; rows that fail validation are reported without preventing the others from
+// being imported. A dry run reports the same outcome without changing any gin. The job
+// result is the import record with its summary.
+func (s *Service) Process(ctx context.Context, job *jobs.Job, progress jobs.ProgressFunc) (interface{}, error) {
+	if s.repo == nil || s.gins == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	var payload jobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("decode import job payload: %w", err)
+	}
+
+	upload, err := s.repo.GetByID(ctx, payload.ImportID)
+	if err != nil {
+		return nil, err
+	}
+	switch upload.Status {
+	case JobCompleted:
+		return upload, nil
+	case JobFailed:
+		return nil, jobs.Permanent(fmt.Errorf("import %s: %s", upload.FileName, upload.Summary.Error))
+	}
+
+	upload.Status = JobProcessing
+	if err := s.repo.Update(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	items, failures, err := Parse(upload.Content)
+	if err != nil {
+		return nil, s.fail(ctx, upload, err)
+	}
+
+	total := len(items) + len(failures)
+	ctx = requestctx.WithActor(ctx, upload.UploadedBy)
+	ctx = requestctx.WithRequestID(ctx, job.PublicID.String())
+	outcomes, err := s.gins.Import(ctx, items, search.ImportOptions{
+		DryRun: upload.DryRun,
+		Progress: func(done int) {
+			progress(len(failures)+done, total)
+		},
+	})
+	if err != nil {
+		if ctx.Err() == nil && job.Attempts >= job.MaxAttempts {
+			return nil, s.fail(ctx, upload, err)
+		}
+		return nil, err
+	}
+
+	upload.Status = JobCompleted
+	upload.Summary = summarize(append(outcomes, failures...))
+	upload.SucceededRows = upload.Summary.Created + upload.Summary.Updated + upload.Summary.Unchanged
+	upload.FailedRows = upload.Summary.Failed
+	upload.Content = nil
+	if err := s.repo.Update(ctx, upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// Abandon is the background job failure hook for imports. It marks the import failed and
+// drops its uploaded content when the runner gives up on the job without the handler having
+// done so, for example after the job exceeded its maximum attempts.
+func (s *Service) Abandon(ctx context.Context, job *jobs.Job, message string) error {
+	if s.repo == nil {
+		return ErrRepositoryNotConfigured
+	}
+
+	var payload jobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("decode import job payload: %w", err)
+	}
+
+	upload, err := s.repo.GetByID(ctx, payload.ImportID)
+	if err != nil {
+		return err
+	}
+	if upload.Status == JobCompleted || upload.Status == JobFailed {
+		return nil
+	}
+	return s.markFailed(ctx, upload, message)
+}
+
+// fail records that an import will not be retried and returns err as a permanent job error.
+func (s *Service) fail(ctx context.Context, upload *Job, err error) error {
+	if updateErr := s.markFailed(ctx, upload, err.Error()); updateErr != nil {
+		return fmt.Errorf("%w (marking import failed: %v)", err, updateErr)
+	}
+	return jobs.Permanent(fmt.Errorf("import %s: %w", upload.FileName, err))
+}
+
+func (s *Service) markFailed(ctx context.Context, upload *Job, message string) error {
+	upload.Status = JobFailed
+	upload.Summary = Summary{Rows: []search.ImportOutcome{}, Error: message}
+	upload.Content = nil
+	return s.repo.Update(ctx, upload)
+}
+
+func truncate(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit])
+}
+
+func summarize(outcomes []search.ImportOutcome) Summary {
+	sort.Slice(outcomes, func(i, j int) bool {
+		return outcomes[i].Line < outcomes[j].Line
+	})
+
+	summary := Summary{Rows: outcomes}
+	if summary.Rows == nil {
+		summary.Rows = []search.ImportOutcome{}
+	}
+	for _, outcome := range outcomes {
+		switch outcome.Action {
+		case search.ImportCreated:
+			summary.Created++
+		case search.ImportUpdated:
+			summary.Updated++
+		case search.ImportUnchanged:
+			summary.Unchanged++
+		case search.ImportFailed:
+			summary.Failed++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the lifecycle state of a background job.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Job is a unit of background work persisted in the jobs table. Workers claim jobs with a
+// time-limited lease, so work abandoned by a stopped or crashed process is picked up again.
+type Job struct {
+	ID            uint       `json:"-" gorm:"column:id;primaryKey"`
+	PublicID      uuid.UUID  `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	Kind          string     `json:"kind" gorm:"column:kind;type:varchar(64);not null"`
+	Status        Status     `json:"status" gorm:"column:status;type:varchar(16);not null"`
+	Payload       RawJSON    `json:"-" gorm:"column:payload;type:jsonb;not null"`
+	Result        RawJSON    `json:"result" gorm:"column:result;type:jsonb"`
+	Error         string     `json:"error,omitempty" gorm:"column:error;type:text;not null"`
+	ProgressDone  int        `json:"progress_done" gorm:"column:progress_done;not null"`
+	ProgressTotal int        `json:"progress_total" gorm:"column:progress_total;not null"`
+	Attempts      int        `json:"attempts" gorm:"column:attempts;not null"`
+	MaxAttempts   int        `json:"max_attempts" gorm:"column:max_attempts;not null"`
+	RunAfter      time.Time  `json:"-" gorm:"column:run_after;not null"`
+	LockedBy      string     `json:"-" gorm:"column:locked_by;type:varchar(255);not null"`
+	LockedUntil   *time.Time `json:"-" gorm:"column:locked_until"`
+	StartedAt     *time.Time `json:"started_at" gorm:"column:started_at"`
+	FinishedAt    *time.Time `json:"finished_at" gorm:"column:finished_at"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// TableName specifies the PostgreSQL table name for jobs.
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// RawJSON holds an already encoded JSON document stored in a jsonb column.
+type RawJSON []byte
+
+// Value implements driver.Valuer.
+func (r RawJSON) Value() (driver.Value, error) {
+	if r == nil {
+		return nil, nil
+	}
+	return string(r), nil
+}
+
+// Scan implements sql.Scanner.
+func (r *RawJSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*r = nil
+	case []byte:
+		*r = append(RawJSON(nil), v...)
+	case string:
+		*r = RawJSON(v)
+	default:
+		return fmt.Errorf("unsupported JSON value %T", value)
+	}
+	return nil
+}
+
+// MarshalJSON emits the stored document as is, or null when empty.
+func (r RawJSON) MarshalJSON() ([]byte, error) {
+	if len(r) == 0 {
+		return []byte("null"), nil
+	}
+	return r, nil
+}
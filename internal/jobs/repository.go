@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines access methods to the persistent job queue.
+type Repository interface {
+	Enqueue(ctx context.Context, job *Job) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+	Claim(ctx context.Context, worker string, lease time.Duration) (*Job, error)
+	Heartbeat(ctx context.Context, job *Job, lease time.Duration) error
+	Progress(ctx context.Context, job *Job, done, total int) error
+	Complete(ctx context.Context, job *Job, result RawJSON) error
+	Fail(ctx context.Context, job *Job, message string) error
+	Retry(ctx context.Context, job *Job, message string, runAfter time.Time) error
+	Release(ctx context.Context, job *Job) error
+}
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+// NewRepository constructs a Repository backed by GORM.
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) Enqueue(ctx context.Context, job *Job) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *gormRepository) GetByID(ctx context.Context, id uuid.UUID) (*Job, error) {
+	var job Job
+
+	err := r.db.WithContext(ctx).Where("public_id = ?", id).Take(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// claimQuery leases the oldest runnable job: a queued job that is due, or a running job
+// whose lease expired because its worker stopped. SKIP LOCKED lets concurrent workers claim
+// different jobs without waiting on each other.
+const claimQuery = `
+UPDATE jobs SET
+    status = 'running',
+    attempts = attempts + 1,
+    locked_by = @worker,
+    locked_until = NOW() + make_interval(secs => @lease),
+    started_at = COALESCE(started_at, NOW())
+WHERE id = (
+    SELECT id FROM jobs
+    WHERE (status = 'queued' AND run_after <= NOW())
+       OR (status = 'running' AND locked_until < NOW())
+    ORDER BY run_after, id
+    FOR UPDATE SKIP LOCKED
+    LIMIT 1
+)
+RETURNING *`
+
+func (r *gormRepository) Claim(ctx context.Context, worker string, lease time.Duration) (*Job, error) {
+	var claimed []Job
+	err := r.db.WithContext(ctx).Raw(claimQuery, map[string]interface{}{
+		"worker": worker,
+		"lease":  lease.Seconds(),
+	}).Scan(&claimed).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	return &claimed[0], nil
+}
+
+// owned scopes an update to a job still leased by the worker that claimed it.
+func (r *gormRepository) owned(ctx context.Context, job *Job) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", job.ID, StatusRunning, job.LockedBy)
+}
+
+func leaseResult(result *gorm.DB) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *gormRepository) Heartbeat(ctx context.Context, job *Job, lease time.Duration) error {
+	return leaseResult(r.owned(ctx, job).
+		Update("locked_until", gorm.Expr("NOW() + make_interval(secs => ?)", lease.Seconds())))
+}
+
+func (r *gormRepository) Progress(ctx context.Context, job *Job, done, total int) error {
+	return leaseResult(r.owned(ctx, job).Updates(map[string]interface{}{
+		"progress_done":  done,
+		"progress_total": total,
+	}))
+}
+
+func (r *gormRepository) Complete(ctx context.Context, job *Job, result RawJSON) error {
+	return leaseResult(r.owned(ctx, job).Updates(map[string]interface{}{
+		"status":       StatusCompleted,
+		"result":       result,
+		"error":        "",
+		"locked_by":    "",
+		"locked_until": nil,
+		"finished_at":  gorm.Expr("NOW()"),
+	}))
+}
+
+func (r *gormRepository) Fail(ctx context.Context, job *Job, message string) error {
+	return leaseResult(r.owned(ctx, job).Updates(map[string]interface{}{
+		"status":       StatusFailed,
+		"error":        message,
+		"locked_by":    "",
+		"locked_until": nil,
+		"finished_at":  gorm.Expr("NOW()"),
+	}))
+}
+
+func (r *gormRepository) Retry(ctx context.Context, job *Job, message string, runAfter time.Time) error {
+	return leaseResult(r.owned(ctx, job).Updates(map[string]interface{}{
+		"status":       StatusQueued,
+		"error":        message,
+		"run_after":    runAfter,
+		"locked_by":    "",
+		"locked_until": nil,
+	}))
+}
+
+// Release hands an interrupted job back to the queue without counting the attempt.
+func (r *gormRepository) Release(ctx context.Context, job *Job) error {
+	return leaseResult(r.owned(ctx, job).Updates(map[string]interface{}{
+		"status":       StatusQueued,
+		"attempts":     gorm.Expr("GREATEST(attempts - 1, 0)"),
+		"locked_by":    "",
+		"locked_until": nil,
+	}))
+}
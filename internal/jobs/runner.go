@@ -0,0 +1,219 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// retryBackoff is the delay before the first retry of a failed job; later retries wait longer.
+const retryBackoff = 10 * time.Second
+
+// ProgressFunc reports how many of a job's units of work are done.
+type ProgressFunc func(done, total int)
+
+// Handler runs one job. Handlers must be safe to run again after an interruption: a job whose
+// worker stops before completing it is claimed again and restarted from the beginning.
+type Handler func(ctx context.Context, job *Job, progress ProgressFunc) (result interface{}, err error)
+
+// FailureHook is called after a job is marked failed, including when the runner fails it
+// without running its handler, for example once it has exceeded its maximum attempts. Hooks
+// may run more than once for the same job and must be idempotent.
+type FailureHook func(ctx context.Context, job *Job, message string) error
+
+// permanentError marks a handler error that retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that the runner fails the job immediately instead of retrying it.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// RunnerConfig tunes the in-process job runner.
+type RunnerConfig struct {
+	Workers       int
+	PollInterval  time.Duration
+	LeaseDuration time.Duration
+}
+
+// Runner claims jobs from the queue and executes them in the current process.
+type Runner struct {
+	repo         Repository
+	logger       *zap.Logger
+	cfg          RunnerConfig
+	name         string
+	handlers     map[string]Handler
+	failureHooks map[string]FailureHook
+}
+
+// NewRunner constructs a Runner. Handlers must be registered before calling Run.
+func NewRunner(repo Repository, logger *zap.Logger, cfg RunnerConfig) *Runner {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+
+	hostname, _ := os.Hostname()
+	return &Runner{
+		repo:         repo,
+		logger:       logger,
+		cfg:          cfg,
+		name:         fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewString()[:8]),
+		handlers:     make(map[string]Handler),
+		failureHooks: make(map[string]FailureHook),
+	}
+}
+
+// Register sets the handler for jobs of the given kind.
+func (r *Runner) Register(kind string, handler Handler) {
+	r.handlers[kind] = handler
+}
+
+// OnFailure sets the hook run when a job of the given kind is marked failed.
+func (r *Runner) OnFailure(kind string, hook FailureHook) {
+	r.failureHooks[kind] = hook
+}
+
+// Run polls for jobs until ctx is cancelled. Jobs interrupted by cancellation are released
+// back to the queue so that the next process resumes them.
+func (r *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < r.cfg.Workers; i++ {
+		wg.Add(1)
+		go func(worker string) {
+			defer wg.Done()
+			r.work(ctx, worker)
+		}(fmt.Sprintf("%s/%d", r.name, i))
+	}
+	wg.Wait()
+}
+
+func (r *Runner) work(ctx context.Context, worker string) {
+	for {
+		job, err := r.repo.Claim(ctx, worker, r.cfg.LeaseDuration)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error("claim job failed", zap.String("worker", worker), zap.Error(err))
+		}
+		if job != nil {
+			r.execute(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job *Job) {
+	logger := r.logger.With(
+		zap.String("job_id", job.PublicID.String()),
+		zap.String("kind", job.Kind),
+		zap.Int("attempt", job.Attempts),
+	)
+	// Bookkeeping must still reach the database while the runner is shutting down.
+	bookkeeping := context.Background()
+
+	handler, ok := r.handlers[job.Kind]
+	if !ok {
+		r.fail(bookkeeping, logger, job, fmt.Sprintf("no handler registered for job kind %q", job.Kind))
+		return
+	}
+	if job.Attempts > job.MaxAttempts {
+		r.fail(bookkeeping, logger, job, "job exceeded its maximum attempts")
+		return
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go r.heartbeat(jobCtx, cancel, logger, job)
+
+	logger.Info("job started")
+	result, err := handler(jobCtx, job, func(done, total int) {
+		if err := r.repo.Progress(bookkeeping, job, done, total); err != nil {
+			logger.Warn("record job progress failed", zap.Error(err))
+		}
+	})
+
+	// A handler that succeeded is recorded as completed even if shutdown began meanwhile:
+	// releasing it would run the job a second time.
+	switch {
+	case err == nil:
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			r.fail(bookkeeping, logger, job, fmt.Sprintf("encode result: %v", err))
+			return
+		}
+		logger.Info("job completed")
+		r.finish(logger, r.repo.Complete(bookkeeping, job, encoded))
+	case ctx.Err() != nil:
+		logger.Info("job interrupted by shutdown; releasing")
+		r.finish(logger, r.repo.Release(bookkeeping, job))
+	case jobCtx.Err() != nil:
+		logger.Warn("job lease lost; abandoning")
+	case job.Attempts < job.MaxAttempts && !errors.As(err, new(*permanentError)):
+		runAfter := time.Now().Add(retryBackoff * time.Duration(job.Attempts*job.Attempts))
+		logger.Warn("job failed; retrying", zap.Error(err), zap.Time("run_after", runAfter))
+		r.finish(logger, r.repo.Retry(bookkeeping, job, err.Error(), runAfter))
+	default:
+		logger.Error("job failed", zap.Error(err))
+		r.fail(bookkeeping, logger, job, err.Error())
+	}
+}
+
+// fail marks job failed and then runs the failure hook registered for its kind, so that
+// records owned by the job are settled even when its handler never ran.
+func (r *Runner) fail(ctx context.Context, logger *zap.Logger, job *Job, message string) {
+	if err := r.repo.Fail(ctx, job, message); err != nil {
+		r.finish(logger, err)
+		return
+	}
+
+	if hook, ok := r.failureHooks[job.Kind]; ok {
+		if err := hook(ctx, job, message); err != nil {
+			logger.Error("job failure hook failed", zap.Error(err))
+		}
+	}
+}
+
+// heartbeat extends the job's lease while it runs and cancels the job if the lease is lost.
+func (r *Runner) heartbeat(ctx context.Context, cancel context.CancelFunc, logger *zap.Logger, job *Job) {
+	ticker := time.NewTicker(r.cfg.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := r.repo.Heartbeat(ctx, job, r.cfg.LeaseDuration)
+			if errors.Is(err, ErrLeaseLost) {
+				cancel()
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				logger.Warn("extend job lease failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (r *Runner) finish(logger *zap.Logger, err error) {
+	if err != nil {
+		logger.Error("update job state failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,241 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeRepository records the state transitions the runner requests for claimed jobs.
+type fakeRepository struct {
+	mu     sync.Mutex
+	queue  []*Job
+	calls  []string
+	errors []string
+}
+
+func (f *fakeRepository) record(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeRepository) Enqueue(_ context.Context, job *Job) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queue = append(f.queue, job)
+	return nil
+}
+
+func (f *fakeRepository) GetByID(context.Context, uuid.UUID) (*Job, error) {
+	return nil, ErrNotFound
+}
+
+func (f *fakeRepository) Claim(_ context.Context, worker string, _ time.Duration) (*Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.queue) == 0 {
+		return nil, nil
+	}
+	job := f.queue[0]
+	f.queue = f.queue[1:]
+	job.Status = StatusRunning
+	job.Attempts++
+	job.LockedBy = worker
+	return job, nil
+}
+
+func (f *fakeRepository) Heartbeat(context.Context, *Job, time.Duration) error { return nil }
+
+func (f *fakeRepository) Progress(context.Context, *Job, int, int) error { return nil }
+
+func (f *fakeRepository) Complete(context.Context, *Job, RawJSON) error {
+	f.record("complete")
+	return nil
+}
+
+func (f *fakeRepository) Fail(_ context.Context, _ *Job, message string) error {
+	f.record("fail")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors = append(f.errors, message)
+	return nil
+}
+
+func (f *fakeRepository) Retry(context.Context, *Job, string, time.Time) error {
+	f.record("retry")
+	return nil
+}
+
+func (f *fakeRepository) Release(context.Context, *Job) error {
+	f.record("release")
+	return nil
+}
+
+func newTestRunner(repo Repository) *Runner {
+	return NewRunner(repo, zap.NewNop(), RunnerConfig{
+		PollInterval:  time.Millisecond,
+		LeaseDuration: time.Minute,
+	})
+}
+
+func claimOne(t *testing.T, repo *fakeRepository, job *Job) *Job {
+	t.Helper()
+	if err := repo.Enqueue(context.Background(), job); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	claimed, err := repo.Claim(context.Background(), "test", time.Minute)
+	if err != nil || claimed == nil {
+		t.Fatalf("claim: job=%v err=%v", claimed, err)
+	}
+	return claimed
+}
+
+func TestRunnerRetriesUntilMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		err      error
+		want     string
+	}{
+		{name: "transient error is retried", attempts: 0, err: errors.New("boom"), want: "retry"},
+		{name: "last attempt fails", attempts: 2, err: errors.New("boom"), want: "fail"},
+		{name: "permanent error is not retried", attempts: 0, err: Permanent(errors.New("bad input")), want: "fail"},
+		{name: "success completes", attempts: 0, want: "complete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{}
+			runner := newTestRunner(repo)
+			runner.Register("test", func(context.Context, *Job, ProgressFunc) (interface{}, error) {
+				return nil, tt.err
+			})
+
+			job := claimOne(t, repo, &Job{Kind: "test", Attempts: tt.attempts, MaxAttempts: 3})
+			runner.execute(context.Background(), job)
+
+			if len(repo.calls) != 1 || repo.calls[0] != tt.want {
+				t.Fatalf("calls = %v, want [%s]", repo.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunnerCompletesJobThatSucceededDuringShutdown(t *testing.T) {
+	repo := &fakeRepository{}
+	runner := newTestRunner(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.Register("test", func(context.Context, *Job, ProgressFunc) (interface{}, error) {
+		cancel()
+		return "done", nil
+	})
+
+	runner.execute(ctx, claimOne(t, repo, &Job{Kind: "test", MaxAttempts: 3}))
+
+	if len(repo.calls) != 1 || repo.calls[0] != "complete" {
+		t.Fatalf("calls = %v, want [complete]", repo.calls)
+	}
+}
+
+func TestRunnerReleasesJobInterruptedByShutdown(t *testing.T) {
+	repo := &fakeRepository{}
+	runner := newTestRunner(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.Register("test", func(ctx context.Context, _ *Job, _ ProgressFunc) (interface{}, error) {
+		cancel()
+		return nil, ctx.Err()
+	})
+
+	runner.execute(ctx, claimOne(t, repo, &Job{Kind: "test", MaxAttempts: 3}))
+
+	if len(repo.calls) != 1 || repo.calls[0] != "release" {
+		t.Fatalf("calls = %v, want [release]", repo.calls)
+	}
+}
+
+func TestRunnerRunsFailureHookWithoutHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		register bool
+		attempts int
+	}{
+		{name: "no handler registered", register: false},
+		{name: "maximum attempts exceeded", register: true, attempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{}
+			runner := newTestRunner(repo)
+			if tt.register {
+				runner.Register("test", func(context.Context, *Job, ProgressFunc) (interface{}, error) {
+					t.Fatal("handler must not run")
+					return nil, nil
+				})
+			}
+
+			var hooked []string
+			runner.OnFailure("test", func(_ context.Context, _ *Job, message string) error {
+				hooked = append(hooked, message)
+				return nil
+			})
+
+			runner.execute(context.Background(), claimOne(t, repo, &Job{Kind: "test", Attempts: tt.attempts, MaxAttempts: 3}))
+
+			if len(repo.calls) != 1 || repo.calls[0] != "fail" {
+				t.Fatalf("calls = %v, want [fail]", repo.calls)
+			}
+			if len(hooked) != 1 || hooked[0] != repo.errors[0] {
+				t.Fatalf("failure hook messages = %v, want [%s]", hooked, repo.errors[0])
+			}
+		})
+	}
+}
+
+func TestRunnerClaimsQueuedJobs(t *testing.T) {
+	repo := &fakeRepository{}
+	runner := newTestRunner(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ran []uuid.UUID
+	runner.Register("test", func(_ context.Context, job *Job, _ ProgressFunc) (interface{}, error) {
+		ran = append(ran, job.PublicID)
+		if len(ran) == 2 {
+			cancel()
+		}
+		return nil, nil
+	})
+
+	first, second := uuid.New(), uuid.New()
+	_ = repo.Enqueue(ctx, &Job{PublicID: first, Kind: "test", MaxAttempts: 3})
+	_ = repo.Enqueue(ctx, &Job{PublicID: second, Kind: "test", MaxAttempts: 3})
+
+	done := make(chan struct{})
+	go func() {
+		runner.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runner did not stop after cancellation")
+	}
+
+	if len(ran) != 2 || ran[0] != first || ran[1] != second {
+		t.Fatalf("ran = %v, want [%s %s]", ran, first, second)
+	}
+	if len(repo.calls) != 2 || repo.calls[0] != "complete" || repo.calls[1] != "complete" {
+		t.Fatalf("calls = %v, want both jobs completed", repo.calls)
+	}
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxAttempts is how many times a job is tried before it is marked failed.
+const DefaultMaxAttempts = 3
+
+var (
+	// ErrRepositoryNotConfigured indicates that the service was constructed without a backing repository.
+	ErrRepositoryNotConfigured = errors.New("job repository not configured")
+	// ErrNotFound is returned when the requested job does not exist.
+	ErrNotFound = errors.New("job not found")
+	// ErrLeaseLost is returned when a worker updates a job it no longer holds, typically
+	// because its lease expired and another worker claimed the job.
+	ErrLeaseLost = errors.New("job lease lost")
+)
+
+// Service enqueues background jobs and reports their state.
+type Service struct {
+	repo Repository
+}
+
+// NewService constructs a new Service using the provided repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Enqueue persists a job of the given kind. The payload is encoded as JSON and handed to the
+// handler registered for kind when a worker runs the job.
+func (s *Service) Enqueue(ctx context.Context, kind string, payload interface{}) (*Job, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s job payload: %w", kind, err)
+	}
+
+	job := &Job{
+		PublicID:    uuid.New(),
+		Kind:        kind,
+		Status:      StatusQueued,
+		Payload:     encoded,
+		MaxAttempts: DefaultMaxAttempts,
+		RunAfter:    time.Now(),
+	}
+	if err := s.repo.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Get retrieves a job with its progress, result and last error.
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*Job, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
@@ -0,0 +1,49 @@
+package moderation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"gin-mania-backend/internal/tasting"
+)
+
+// Action is a moderator's decision on a tasting.
+type Action string
+
+const (
+	ActionApprove Action = "approve"
+	ActionReject  Action = "reject"
+)
+
+// targetStatus maps each action onto the tasting status it produces.
+var targetStatus = map[Action]tasting.Status{
+	ActionApprove: tasting.StatusApproved,
+	ActionReject:  tasting.StatusRejected,
+}
+
+// Event records one moderation decision, written in the same transaction as the status
+// change it describes.
+type Event struct {
+	ID         uint           `json:"-" gorm:"column:id;primaryKey"`
+	PublicID   uuid.UUID      `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	TastingID  uint           `json:"-" gorm:"column:tasting_id;not null"`
+	Action     Action         `json:"action" gorm:"column:action;type:varchar(16);not null"`
+	FromStatus tasting.Status `json:"from_status" gorm:"column:from_status;type:varchar(16);not null"`
+	ToStatus   tasting.Status `json:"to_status" gorm:"column:to_status;type:varchar(16);not null"`
+	Reason     string         `json:"reason" gorm:"column:reason;type:text;not null"`
+	Actor      string         `json:"actor" gorm:"column:actor;type:varchar(255);not null"`
+	RequestID  string         `json:"request_id" gorm:"column:request_id;type:varchar(255);not null"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"column:created_at"`
+}
+
+// TableName specifies the PostgreSQL table name for moderation events.
+func (Event) TableName() string {
+	return "moderation_events"
+}
+
+// Decision is a moderator's request to approve or reject a tasting.
+type Decision struct {
+	Action Action `json:"action"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,110 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gin-mania-backend/internal/tasting"
+)
+
+// Repository defines access methods to the moderation queue.
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]tasting.Tasting, int64, error)
+	Decide(ctx context.Context, id uuid.UUID, decide func(*tasting.Tasting) (*Event, error)) (*tasting.Tasting, error)
+}
+
+// ListFilter represents filtering and pagination options for the moderation queue.
+type ListFilter struct {
+	Statuses []tasting.Status
+	UserID   string
+	Limit    int
+	Offset   int
+}
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+// NewRepository constructs a Repository backed by GORM.
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) List(ctx context.Context, filter ListFilter) ([]tasting.Tasting, int64, error) {
+	var tastings []tasting.Tasting
+	var total int64
+
+	if err := applyListFilter(r.db.WithContext(ctx).Model(&tasting.Tasting{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tx := applyListFilter(r.db.WithContext(ctx).Model(&tasting.Tasting{}), filter).Select(tasting.Columns)
+
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		tx = tx.Offset(filter.Offset)
+	}
+
+	if err := tx.Order("created_at ASC, id ASC").Find(&tastings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return tastings, total, nil
+}
+
+func applyListFilter(tx *gorm.DB, filter ListFilter) *gorm.DB {
+	statuses := make([]string, len(filter.Statuses))
+	for i, status := range filter.Statuses {
+		statuses[i] = string(status)
+	}
+	tx = tx.Where("status IN ?", statuses)
+
+	if filter.UserID != "" {
+		tx = tx.Where("user_id = ?", filter.UserID)
+	}
+
+	return tx
+}
+
+// Decide locks the tasting, lets decide change its status and describe the decision, and
+// stores both in one transaction. The row lock serialises concurrent moderators so that
+// each event records the status it actually moved the tasting from.
+func (r *gormRepository) Decide(ctx context.Context, id uuid.UUID, decide func(*tasting.Tasting) (*Event, error)) (*tasting.Tasting, error) {
+	var result tasting.Tasting
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Select(tasting.Columns).
+			Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "tasting_logs"}}).
+			Where("public_id = ?", id).
+			Take(&result).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return tasting.ErrNotFound
+			}
+			return err
+		}
+
+		event, err := decide(&result)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&result).Select("status", "updated_at").Updates(&result).Error; err != nil {
+			return err
+		}
+
+		event.TastingID = result.ID
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
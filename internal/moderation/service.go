@@ -0,0 +1,127 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"gin-mania-backend/internal/tasting"
+	"gin-mania-backend/pkg/requestctx"
+)
+
+const (
+	// DefaultListLimit is the page size used when the queue listing does not specify a limit.
+	DefaultListLimit = 20
+	// MaxListLimit bounds the page size of the queue listing.
+	MaxListLimit = 100
+	// maxReasonLength bounds the reason given for a decision.
+	maxReasonLength = 500
+)
+
+var (
+	// ErrRepositoryNotConfigured indicates that the service was constructed without a backing repository.
+	ErrRepositoryNotConfigured = errors.New("moderation repository not configured")
+	// ErrInvalidPagination is returned when the requested pagination parameters are out of range.
+	ErrInvalidPagination = errors.New("invalid pagination parameters")
+	// ErrInvalidDecision is returned when a decision fails validation.
+	ErrInvalidDecision = errors.New("invalid moderation decision")
+	// ErrAlreadyDecided is returned when a tasting already has the status a decision would give it.
+	ErrAlreadyDecided = errors.New("tasting already has that status")
+	// ErrActorRequired is returned when a decision is made without an identified moderator.
+	ErrActorRequired = errors.New("moderation decisions require an identified moderator")
+)
+
+// Service lets moderators review user-submitted tastings before they count towards public
+// ratings. Every decision is recorded as an Event attributed to the acting moderator.
+type Service struct {
+	repo Repository
+}
+
+// NewService constructs a new Service using the provided repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// List returns tastings with the given statuses, oldest first, along with the total number
+// of matches. An empty status list selects pending tastings.
+func (s *Service) List(ctx context.Context, filter ListFilter) ([]tasting.Tasting, int64, error) {
+	if s.repo == nil {
+		return nil, 0, ErrRepositoryNotConfigured
+	}
+
+	if filter.Limit < 0 || filter.Limit > MaxListLimit || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit must be between 0 and %d and offset non-negative", ErrInvalidPagination, MaxListLimit)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultListLimit
+	}
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []tasting.Status{tasting.StatusPending}
+	}
+
+	return s.repo.List(ctx, filter)
+}
+
+// Decide approves or rejects a tasting. Rejections require a reason. Moderators may reverse
+// an earlier decision, but a decision that would not change the status is refused, as is any
+// decision when ctx carries no actor to attribute it to.
+func (s *Service) Decide(ctx context.Context, id uuid.UUID, decision Decision) (*tasting.Tasting, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	actor := requestctx.Actor(ctx)
+	if actor == "" {
+		return nil, ErrActorRequired
+	}
+
+	decision.Action = Action(strings.ToLower(strings.TrimSpace(string(decision.Action))))
+	decision.Reason = strings.TrimSpace(decision.Reason)
+	if err := validateDecision(decision); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Decide(ctx, id, func(t *tasting.Tasting) (*Event, error) {
+		to := targetStatus[decision.Action]
+		if t.Status == to {
+			return nil, fmt.Errorf("%w: tasting is already %s", ErrAlreadyDecided, to)
+		}
+
+		event := &Event{
+			PublicID:   uuid.New(),
+			Action:     decision.Action,
+			FromStatus: t.Status,
+			ToStatus:   to,
+			Reason:     decision.Reason,
+			Actor:      actor,
+			RequestID:  requestctx.RequestID(ctx),
+		}
+		t.Status = to
+		return event, nil
+	})
+}
+
+func validateDecision(decision Decision) error {
+	var problems []string
+
+	if _, ok := targetStatus[decision.Action]; !ok {
+		problems = append(problems, "action must be approve or reject")
+	}
+
+	if decision.Action == ActionReject && decision.Reason == "" {
+		problems = append(problems, "reason is required when rejecting")
+	}
+
+	if utf8.RuneCountInString(decision.Reason) > maxReasonLength {
+		problems = append(problems, fmt.Sprintf("reason must be at most %d characters", maxReasonLength))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidDecision, strings.Join(problems, "; "))
+	}
+	return nil
+}
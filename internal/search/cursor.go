@@ -0,0 +1,41 @@
+package search
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// cursor identifies the last row of a page for keyset pagination. It records the sort
+// order it was issued for together with that row's sort key and ID, and is serialized as
+// base64url-encoded JSON so clients treat it as an opaque token.
+type cursor struct {
+	Sort  SortOrder `json:"s"`
+	Value string    `json:"v"`
+	ID    uint      `json:"i"`
+}
+
+func encodeCursor(order SortOrder, g Gin) string {
+	payload, _ := json.Marshal(cursor{Sort: order, Value: sortSpecs[order].value(g), ID: g.ID})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeCursor parses token and checks that it was issued for the given sort order.
+func decodeCursor(token string, order SortOrder) (cursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidPagination)
+	}
+
+	var c cursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.ID == 0 {
+		return cursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidPagination)
+	}
+	if c.Sort != order {
+		return cursor{}, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidPagination)
+	}
+	if _, err := sortSpecs[order].parseValue(c.Value); err != nil {
+		return cursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidPagination)
+	}
+	return c, nil
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.FixedZone("JST", 9*60*60))
+	gin := Gin{ID: 42, Name: "Roku", Country: "Japan", CreatedAt: created, UpdatedAt: created}
+
+	tests := []struct {
+		order SortOrder
+		value string
+	}{
+		{order: SortNameAsc, value: "Roku"},
+		{order: SortCountryDesc, value: "Japan"},
+		{order: SortCreatedAtAsc, value: "2024-03-01T03:30:45.123456789Z"},
+		{order: SortUpdatedAtDesc, value: "2024-03-01T03:30:45.123456789Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			decoded, err := decodeCursor(encodeCursor(tt.order, gin), tt.order)
+			if err != nil {
+				t.Fatalf("decodeCursor returned error: %v", err)
+			}
+			if decoded.Sort != tt.order || decoded.Value != tt.value || decoded.ID != gin.ID {
+				t.Fatalf("decoded cursor = %+v, want sort %s value %q id %d", decoded, tt.order, tt.value, gin.ID)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsInvalidTokens(t *testing.T) {
+	encode := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		order SortOrder
+	}{
+		{name: "not base64", token: "!!!", order: SortNameAsc},
+		{name: "not JSON", token: encode("roku"), order: SortNameAsc},
+		{name: "missing id", token: encode(`{"s":"name","v":"Roku"}`), order: SortNameAsc},
+		{name: "different sort order", token: encodeCursor(SortNameDesc, Gin{ID: 1, Name: "Roku"}), order: SortNameAsc},
+		{name: "malformed timestamp", token: encode(`{"s":"created_at","v":"Roku","i":1}`), order: SortCreatedAtAsc},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.token, tt.order); !errors.Is(err, ErrInvalidPagination) {
+				t.Fatalf("error = %v, want ErrInvalidPagination", err)
+			}
+		})
+	}
+}
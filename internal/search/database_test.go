@@ -0,0 +1,53 @@
+package search
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gin-mania-backend/pkg/database"
+)
+
+// openTestDB connects to the migrated PostgreSQL database named by TEST_DATABASE_URL and
+// skips the test when it is not set.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := database.OpenPostgres(context.Background(), database.Config{DSN: dsn})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("retrieve sql DB: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return db
+}
+
+// createTestGin stores a draft gin and removes it, together with its history, when the test
+// ends.
+func createTestGin(t *testing.T, db *gorm.DB, repo Repository, name string) *Gin {
+	t.Helper()
+
+	gin := &Gin{PublicID: uuid.New(), Status: StatusDraft}
+	GinInput{Name: name + " " + uuid.NewString(), Country: "Japan"}.apply(gin)
+	if err := repo.Create(context.Background(), gin, newRevision(context.Background(), RevisionCreate, &Snapshot{})); err != nil {
+		t.Fatalf("create gin: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM tasting_logs WHERE gin_id = ?", gin.ID)
+		db.Exec("DELETE FROM gin WHERE id = ?", gin.ID)
+		db.Exec("DELETE FROM gin_revisions WHERE gin_public_id = ?", gin.PublicID)
+	})
+	return gin
+}
@@ -0,0 +1,290 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gin-mania-backend/pkg/database"
+)
+
+// ImportItem is one row of a bulk import.
+type ImportItem struct {
+	// Line is the source line number reported back with the outcome.
+	Line int
+	// ID selects the gin to update. When nil, the row updates the gin with the same name or
+	// creates a new one.
+	ID *uuid.UUID
+	// Distillery is matched case-insensitively against distillery names and overrides
+	// Input.DistilleryID. Unknown names fail the row.
+	Distillery string
+	Input      GinInput
+}
+
+// ImportAction describes what an import did, or would do in a dry run, with one row.
+type ImportAction string
+
+const (
+	ImportCreated   ImportAction = "created"
+	ImportUpdated   ImportAction = "updated"
+	ImportUnchanged ImportAction = "unchanged"
+	ImportFailed    ImportAction = "failed"
+)
+
+// ImportOutcome reports the result of importing one row.
+type ImportOutcome struct {
+	Line    int          `json:"line"`
+	Action  ImportAction `json:"action"`
+	ID      *uuid.UUID   `json:"id,omitempty"`
+	Changes Diff         `json:"changes,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// ImportOptions control how an import runs.
+type ImportOptions struct {
+	// DryRun reports the outcomes and then rolls every change back.
+	DryRun bool
+	// Progress, when set, is called periodically with the number of items processed so far.
+	Progress func(done int)
+}
+
+// progressInterval is how many rows an import processes between progress reports.
+const progressInterval = 50
+
+// importRow pairs an item with the revision recorded for it and the outcome to report.
+type importRow struct {
+	item     ImportItem
+	revision *Revision
+	outcome  *ImportOutcome
+}
+
+// errDryRun rolls back a dry-run import once every row has been applied.
+var errDryRun = errors.New("dry run")
+
+// Import creates or updates gins in a single transaction. Rows are independent: a row that
+// fails validation or a database constraint is rolled back to its savepoint and reported in
+// its outcome without affecting the others. A dry run reports the same outcomes and then rolls everything back.
+func (s *Service) Import(ctx context.Context, items []ImportItem, opts ImportOptions) ([]ImportOutcome, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	outcomes := make([]ImportOutcome, len(items))
+	rows := make([]importRow, 0, len(items))
+	for i, item := range items {
+		outcomes[i] = ImportOutcome{Line: item.Line}
+
+		candidate := &Gin{Status: StatusDraft}
+		item.Input.apply(candidate)
+		if err := validateGin(candidate); err != nil {
+			outcomes[i].Action = ImportFailed
+			outcomes[i].Error = err.Error()
+			continue
+		}
+
+		rows = append(rows, importRow{
+			item:     item,
+			revision: newRevision(ctx, RevisionImport, nil),
+			outcome:  &outcomes[i],
+		})
+	}
+
+	if progress := opts.Progress; progress != nil {
+		// Rows that failed validation count as processed from the start.
+		skipped := len(items) - len(rows)
+		progress(skipped)
+		opts.Progress = func(done int) { progress(skipped + done) }
+	}
+
+	if err := s.repo.Import(ctx, rows, opts); err != nil {
+		return nil, err
+	}
+	return outcomes, nil
+}
+
+func (r *gormRepository) Import(ctx context.Context, rows []importRow, opts ImportOptions) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, row := range rows {
+			savepoint := fmt.Sprintf("import_row_%d", i)
+			if err := tx.SavePoint(savepoint).Error; err != nil {
+				return err
+			}
+
+			if err := importOne(tx, row); err != nil {
+				if pgErr, ok := database.ConstraintViolation(err); ok {
+					err = fmt.Errorf("%w: %s", ErrInvalidGin, pgErr.Message)
+				}
+				if !errors.Is(err, ErrInvalidGin) && !errors.Is(err, ErrInvalidStatusTransition) {
+					return err
+				}
+				if err := tx.RollbackTo(savepoint).Error; err != nil {
+					return err
+				}
+				row.outcome.Action = ImportFailed
+				row.outcome.Changes = nil
+				row.outcome.Error = err.Error()
+			}
+
+			if done := i + 1; opts.Progress != nil && (done%progressInterval == 0 || done == len(rows)) {
+				opts.Progress(done)
+			}
+		}
+
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	return err
+}
+
+// importOne applies a single row inside the import transaction. Row-level problems are
+// reported as ErrInvalidGin or ErrInvalidStatusTransition, and constraint violations are
+// treated the same way by the caller; any other error aborts the import.
+func importOne(tx *gorm.DB, row importRow) error {
+	input := row.item.Input
+	if name := strings.TrimSpace(row.item.Distillery); name != "" {
+		var distillery struct {
+			PublicID uuid.UUID
+		}
+		err := tx.Table("distilleries").
+			Select("public_id").
+			Where("LOWER(name) = ?", strings.ToLower(name)).
+			Take(&distillery).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: unknown distillery %q", ErrInvalidGin, name)
+			}
+			return err
+		}
+		input.DistilleryID = &distillery.PublicID
+	}
+
+	existing, err := findImportTarget(tx, row.item.ID, input.Name)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		gin := &Gin{PublicID: uuid.New(), Status: StatusDraft}
+		input.apply(gin)
+		if err := checkTransition(StatusDraft, gin.Status); err != nil {
+			return err
+		}
+		if err := resolveDistillery(tx, gin); err != nil {
+			return err
+		}
+		if err := tx.Create(gin).Error; err != nil {
+			return err
+		}
+		if err := syncBotanicals(tx, gin); err != nil {
+			return err
+		}
+		if err := syncFlavorTags(tx, gin); err != nil {
+			return err
+		}
+
+		row.revision.previous = &Snapshot{}
+		if err := recordRevision(tx, gin, row.revision); err != nil {
+			return err
+		}
+		row.outcome.Action = ImportCreated
+		row.outcome.ID = &gin.PublicID
+		row.outcome.Changes = row.revision.Diff
+		return nil
+	}
+
+	previous := snapshotOf(existing)
+	input.apply(existing)
+	if err := checkTransition(previous.Status, existing.Status); err != nil {
+		return err
+	}
+	// Botanicals are stored with their master spelling, so a row that differs only in case
+	// leaves them unchanged.
+	if existing.Botanicals, err = canonicalBotanicals(tx, existing.Botanicals); err != nil {
+		return err
+	}
+	row.outcome.ID = &existing.PublicID
+
+	// Compare against the normalized input before touching the row so that unchanged rows
+	// produce neither a write nor a revision.
+	pending, err := diffSnapshots(previous, snapshotOf(existing))
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		row.outcome.Action = ImportUnchanged
+		return nil
+	}
+
+	if err := resolveDistillery(tx, existing); err != nil {
+		return err
+	}
+	result := tx.Model(existing).
+		Select(
+			"name", "distillery_id", "country", "region", "abv",
+			"description", "tasting_notes", "image_url", "status", "updated_at",
+		).
+		Updates(existing)
+	if result.Error != nil {
+		return result.Error
+	}
+	if err := syncBotanicals(tx, existing); err != nil {
+		return err
+	}
+	if err := syncFlavorTags(tx, existing); err != nil {
+		return err
+	}
+
+	row.revision.previous = &previous
+	if err := recordRevision(tx, existing, row.revision); err != nil {
+		return err
+	}
+	row.outcome.Action = ImportUpdated
+	row.outcome.Changes = row.revision.Diff
+	return nil
+}
+
+// findImportTarget returns the gin a row updates: the gin with the given identifier, or
+// else the only gin with the same name. It returns nil when the row creates a new gin. The
+// gin stays locked until the import ends, so concurrent edits cannot slip in between the
+// comparison and the write.
+func findImportTarget(tx *gorm.DB, id *uuid.UUID, name string) (*Gin, error) {
+	if id != nil {
+		var gin Gin
+		err := tx.Select(ginColumns).Clauses(lockGins).Where("public_id = ?", *id).Take(&gin).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: no gin with id %s", ErrInvalidGin, id)
+			}
+			return nil, err
+		}
+		return &gin, nil
+	}
+
+	var gins []Gin
+	err := tx.Select(ginColumns).
+		Clauses(lockGins).
+		Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(name))).
+		Limit(2).
+		Find(&gins).Error
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(gins) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &gins[0], nil
+	default:
+		return nil, fmt.Errorf("%w: several gins are named %q; add an id to choose one", ErrInvalidGin, name)
+	}
+}
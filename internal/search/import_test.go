@@ -0,0 +1,51 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestImportIgnoresBotanicalCase(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRepository(db)
+	service := NewService(repo)
+	ctx := context.Background()
+
+	gin := createTestGin(t, db, repo, "Botanical case")
+	botanicals := []string{"Juniper", "Sakura Flower"}
+	if _, err := service.Patch(ctx, gin.PublicID, GinPatch{Botanicals: &botanicals}); err != nil {
+		t.Fatalf("Patch returned error: %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, gin.PublicID)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	before, err := repo.ListRevisions(ctx, gin.PublicID)
+	if err != nil {
+		t.Fatalf("ListRevisions returned error: %v", err)
+	}
+
+	input := GinInput(snapshotOf(stored))
+	input.Botanicals = make([]string, len(stored.Botanicals))
+	for i, name := range stored.Botanicals {
+		input.Botanicals[i] = strings.ToUpper(name)
+	}
+
+	outcomes, err := service.Import(ctx, []ImportItem{{Line: 2, ID: &gin.PublicID, Input: input}}, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+	if outcomes[0].Action != ImportUnchanged {
+		t.Fatalf("outcome = %+v, want unchanged", outcomes[0])
+	}
+
+	after, err := repo.ListRevisions(ctx, gin.PublicID)
+	if err != nil {
+		t.Fatalf("ListRevisions returned error: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Fatalf("import recorded %d revisions, want none", len(after)-len(before))
+	}
+}
@@ -1,23 +1,131 @@
 package search
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
-// Gin represents a gin entry persisted in the database.
+// Gin represents a gin entry persisted in the database. Read-only fields are loaded from
+// related tables by the repository in the same query as the gin row.
 type Gin struct {
-	ID          uint           `json:"-" gorm:"column:id;primaryKey"`
-	Name        string         `json:"name" gorm:"column:name;type:varchar(255);not null"`
-	Country     string         `json:"country" gorm:"column:country;type:varchar(255);not null"`
-	Botanicals  pq.StringArray `json:"botanicals" gorm:"column:botanicals;type:text[]"`
-	Description string         `json:"description" gorm:"column:description;type:text"`
-	CreatedAt   time.Time      `json:"-" gorm:"column:created_at"`
-	UpdatedAt   time.Time      `json:"-" gorm:"column:updated_at"`
+	ID                 uint           `json:"-" gorm:"column:id;primaryKey"`
+	PublicID           uuid.UUID      `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	Name               string         `json:"name" gorm:"column:name;type:varchar(255);not null"`
+	DistilleryID       *uint          `json:"-" gorm:"column:distillery_id"`
+	DistilleryPublicID *uuid.UUID     `json:"distillery_id" gorm:"column:distillery_public_id;type:uuid;->"`
+	Distillery         string         `json:"distillery" gorm:"column:distillery;->"`
+	Country            string         `json:"country" gorm:"column:country;type:varchar(255);not null"`
+	Region             string         `json:"region" gorm:"column:region;type:varchar(255);not null"`
+	ABV                *float64       `json:"abv" gorm:"column:abv;type:numeric(4,1)"`
+	Botanicals         pq.StringArray `json:"botanicals" gorm:"column:botanicals;type:text[];->"`
+	FlavorTags         FlavorTags     `json:"flavor_tags" gorm:"column:flavor_tags;type:jsonb;->"`
+	Description        string         `json:"description" gorm:"column:description;type:text"`
+	TastingNotes       string         `json:"tasting_notes" gorm:"column:tasting_notes;type:text;not null"`
+	ImageURL           string         `json:"image_url" gorm:"column:image_url;type:text;not null"`
+	Status             Status         `json:"status" gorm:"column:status;type:varchar(16);not null;default:draft"`
+	AverageRating      *float64       `json:"average_rating" gorm:"column:average_rating;->"`
+	TastingCount       int64          `json:"tasting_count" gorm:"column:tasting_count;->"`
+	CreatedAt          time.Time      `json:"-" gorm:"column:created_at"`
+	UpdatedAt          time.Time      `json:"-" gorm:"column:updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"column:deleted_at;index"`
+	// Score is the full-text relevance rank, populated only for searches with a query.
+	Score *float64 `json:"score,omitempty" gorm:"column:score;->"`
 }
 
 // TableName specifies the PostgreSQL table name for gins.
 func (Gin) TableName() string {
 	return "gin"
 }
+
+// DeletedGin is a soft-deleted gin together with the time it was deleted.
+type DeletedGin struct {
+	Gin
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Botanical is an entry in the botanicals master table, which gins reference through the
+// gin_botanicals join table.
+type Botanical struct {
+	ID        uint      `json:"-" gorm:"column:id;primaryKey"`
+	PublicID  uuid.UUID `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	Name      string    `json:"name" gorm:"column:name;type:varchar(255);not null"`
+	CreatedAt time.Time `json:"-" gorm:"column:created_at"`
+}
+
+// TableName specifies the PostgreSQL table name for botanicals.
+func (Botanical) TableName() string {
+	return "botanicals"
+}
+
+// BotanicalCount pairs a botanical with the number of gins that use it.
+type BotanicalCount struct {
+	PublicID uuid.UUID `json:"id" gorm:"column:public_id"`
+	Name     string    `json:"name" gorm:"column:name"`
+	GinCount int64     `json:"gin_count" gorm:"column:gin_count"`
+}
+
+// FlavorTag is an entry in the curated flavor taxonomy, such as "citrus" or "spice".
+type FlavorTag struct {
+	ID    uint   `json:"-" gorm:"column:id;primaryKey"`
+	Code  string `json:"code" gorm:"column:code;type:varchar(64);not null"`
+	Label string `json:"label" gorm:"column:label;type:varchar(255);not null"`
+}
+
+// TableName specifies the PostgreSQL table name for flavor tags.
+func (FlavorTag) TableName() string {
+	return "flavor_tags"
+}
+
+// FlavorTags is the list of tags attached to a gin. It scans from the JSON array built by
+// the repository's tag subquery.
+type FlavorTags []FlavorTag
+
+// Scan implements sql.Scanner.
+func (t *FlavorTags) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*t = FlavorTags{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported flavor tags value %T", value)
+	}
+
+	tags := FlavorTags{}
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		return err
+	}
+	*t = tags
+	return nil
+}
+
+// FlavorTagCount pairs a flavor tag with the number of gins tagged with it.
+type FlavorTagCount struct {
+	Code     string `json:"code" gorm:"column:code"`
+	Label    string `json:"label" gorm:"column:label"`
+	GinCount int64  `json:"gin_count" gorm:"column:gin_count"`
+}
+
+// SuggestionKind identifies which attribute an autocomplete suggestion completes.
+type SuggestionKind string
+
+const (
+	SuggestionKindName      SuggestionKind = "name"
+	SuggestionKindCountry   SuggestionKind = "country"
+	SuggestionKindBotanical SuggestionKind = "botanical"
+)
+
+// Suggestion is a single typeahead completion.
+type Suggestion struct {
+	Value string         `json:"value" gorm:"column:value"`
+	Kind  SuggestionKind `json:"kind" gorm:"column:kind"`
+}
@@ -0,0 +1,65 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentWritesSeeEachOther(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRepository(db)
+	service := NewService(repo)
+	ctx := context.Background()
+
+	gin := createTestGin(t, db, repo, "Concurrent")
+
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			region := fmt.Sprintf("Region %d", i)
+			_, err := service.Patch(ctx, gin.PublicID, GinPatch{Region: &region})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Patch returned error: %v", err)
+		}
+	}
+
+	revisions, err := repo.ListRevisions(ctx, gin.PublicID)
+	if err != nil {
+		t.Fatalf("ListRevisions returned error: %v", err)
+	}
+	if len(revisions) != writers+1 {
+		t.Fatalf("got %d revisions, want %d", len(revisions), writers+1)
+	}
+
+	// Every write must have started from the state the previous write left behind.
+	for i := 0; i < writers; i++ {
+		newer, older := revisions[i], revisions[i+1]
+		want, _ := json.Marshal(older.Snapshot.Region)
+		change, ok := newer.Diff["region"]
+		if !ok || !bytes.Equal(change.From, want) {
+			t.Fatalf("revision %d changed region from %s, want %s", newer.Number, change.From, want)
+		}
+	}
+
+	stored, err := repo.GetByID(ctx, gin.PublicID)
+	if err != nil {
+		t.Fatalf("GetByID returned error: %v", err)
+	}
+	if stored.Region != revisions[0].Snapshot.Region {
+		t.Fatalf("stored region %q, want the last write %q", stored.Region, revisions[0].Snapshot.Region)
+	}
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPurgeKeepsTastingsAndHistory(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	tasted := createTestGin(t, db, repo, "Tasted")
+	untasted := createTestGin(t, db, repo, "Untasted")
+
+	const subject = "test|purge"
+	if err := db.Exec("INSERT INTO users (auth_subject) VALUES (?) ON CONFLICT DO NOTHING", subject).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	err := db.Exec(
+		"INSERT INTO tasting_logs (user_id, gin_id, rating, tasted_on) VALUES (?, ?, 4, CURRENT_DATE)",
+		subject, tasted.ID,
+	).Error
+	if err != nil {
+		t.Fatalf("create tasting: %v", err)
+	}
+
+	deletedAt := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, gin := range []*Gin{tasted, untasted} {
+		if err := repo.Delete(ctx, gin.PublicID, newRevision(ctx, RevisionDelete, nil)); err != nil {
+			t.Fatalf("delete gin: %v", err)
+		}
+		if err := db.Exec("UPDATE gin SET deleted_at = ? WHERE id = ?", deletedAt, gin.ID).Error; err != nil {
+			t.Fatalf("backdate deletion: %v", err)
+		}
+	}
+
+	purged, err := repo.Purge(ctx, deletedAt.Add(time.Hour), newRevision(ctx, RevisionPurge, nil))
+	if err != nil {
+		t.Fatalf("Purge returned error: %v", err)
+	}
+	if purged != 1 {
+		t.Fatalf("purged %d gins, want 1", purged)
+	}
+
+	var tastings int64
+	if err := db.Table("tasting_logs").Where("gin_id = ?", tasted.ID).Count(&tastings).Error; err != nil {
+		t.Fatalf("count tastings: %v", err)
+	}
+	if tastings != 1 {
+		t.Fatalf("gin with tastings kept %d tastings, want 1", tastings)
+	}
+	if _, err := repo.ListRevisions(ctx, tasted.PublicID); err != nil {
+		t.Fatalf("history of skipped gin: %v", err)
+	}
+
+	var remaining int64
+	if err := db.Unscoped().Model(&Gin{}).Where("id = ?", untasted.ID).Count(&remaining).Error; err != nil {
+		t.Fatalf("count gins: %v", err)
+	}
+	if remaining != 0 {
+		t.Fatal("gin without tastings was not purged")
+	}
+
+	revisions, err := repo.ListRevisions(ctx, untasted.PublicID)
+	if err != nil {
+		t.Fatalf("history of purged gin: %v", err)
+	}
+	var actions []RevisionAction
+	for _, revision := range revisions {
+		actions = append(actions, revision.Action)
+	}
+	if len(actions) != 3 || actions[0] != RevisionPurge || actions[1] != RevisionDelete || actions[2] != RevisionCreate {
+		t.Fatalf("purged gin history = %v, want [purge delete create]", actions)
+	}
+	if revisions[0].Snapshot.Name != untasted.Name {
+		t.Fatalf("tombstone snapshot name = %q, want %q", revisions[0].Snapshot.Name, untasted.Name)
+	}
+}
@@ -2,23 +2,136 @@ package search
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gin-mania-backend/pkg/database"
 )
 
 // Repository defines access methods to gin data storage.
 type Repository interface {
-	Search(ctx context.Context, filter SearchFilter) ([]Gin, error)
+	Search(ctx context.Context, filter SearchFilter) (SearchResult, error)
+	SimilarNames(ctx context.Context, query string, limit int) ([]string, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Gin, error)
+	Create(ctx context.Context, gin *Gin, revision *Revision) error
+	Modify(ctx context.Context, id uuid.UUID, revision *Revision, modify func(*Gin) error) (*Gin, error)
+	Delete(ctx context.Context, id uuid.UUID, revision *Revision) error
+	ListDeleted(ctx context.Context, limit, offset int) ([]Gin, int64, error)
+	Restore(ctx context.Context, id uuid.UUID, revision *Revision) error
+	ListRevisions(ctx context.Context, id uuid.UUID) ([]Revision, error)
+	GetRevision(ctx context.Context, id uuid.UUID, number int) (*Revision, error)
+	Import(ctx context.Context, rows []importRow, opts ImportOptions) error
+	Export(ctx context.Context, filter SearchFilter, each func(Gin) error) error
+	Purge(ctx context.Context, deletedBefore time.Time, revision *Revision) (int64, error)
+	ListBotanicals(ctx context.Context) ([]BotanicalCount, error)
+	ListFlavorTags(ctx context.Context) ([]FlavorTagCount, error)
 }
 
+// BotanicalMatch controls how multiple botanical filters are combined.
+type BotanicalMatch string
+
+const (
+	// BotanicalMatchAny matches gins containing at least one of the requested botanicals.
+	BotanicalMatchAny BotanicalMatch = "any"
+	// BotanicalMatchAll matches gins containing every requested botanical.
+	BotanicalMatchAll BotanicalMatch = "all"
+)
+
+const (
+	// fuzzyMatchThreshold is the minimum pg_trgm word similarity for a name to match in fuzzy mode.
+	fuzzyMatchThreshold = 0.5
+	// suggestionThreshold is the looser word similarity used for "did you mean" suggestions.
+	suggestionThreshold = 0.3
+)
+
 // SearchFilter represents filtering and pagination options supported by the repository.
 type SearchFilter struct {
-	Query  string
-	Limit  int
-	Offset int
+	Query string
+	// Fuzzy additionally matches names that are similar to Query, tolerating typos.
+	Fuzzy          bool
+	Name           string
+	Distillery     string
+	DistilleryID   *uuid.UUID
+	Country        string
+	Region         string
+	ABVMin         *float64
+	ABVMax         *float64
+	Botanicals     []string
+	BotanicalMatch BotanicalMatch
+	// FlavorTags lists flavor tag codes; matching gins carry every listed tag.
+	FlavorTags []string
+	// Statuses restricts results to the listed publication states. When empty, only
+	// published gins match, so public callers never see drafts or archived entries.
+	Statuses []Status
+	Limit    int
+	Offset   int
+	// Cursor is an opaque keyset token returned as SearchResult.NextCursor. It is mutually
+	// exclusive with Offset.
+	Cursor string
+	// Sort selects the result ordering. When empty, results are ranked by relevance if a
+	// query is present and ordered by DefaultSortOrder otherwise.
+	Sort SortOrder
 }
 
+func (f SearchFilter) sortOrder() SortOrder {
+	switch {
+	case f.Sort != "":
+		return f.Sort
+	case strings.TrimSpace(f.Query) != "":
+		return SortRelevance
+	default:
+		return DefaultSortOrder
+	}
+}
+
+// SearchResult holds one page of matching gins along with the total number of matches.
+type SearchResult struct {
+	Gins  []Gin
+	Total int64
+	// HasMore reports whether further rows follow this page.
+	HasMore bool
+	// Suggestions lists similarly named gins when a strict query matched nothing.
+	Suggestions []string
+	// NextCursor can be passed back as SearchFilter.Cursor to fetch the following page. It is
+	// empty on the last page and for orderings that do not support keyset pagination.
+	NextCursor string
+}
+
+// ginColumns selects gin rows together with their distillery, their botanical names in
+// catalogue order, their flavor tags as a JSON array, and their rating aggregates over
+// approved tastings, all in a single round trip.
+const ginColumns = `gin.*,
+(SELECT d.public_id FROM distilleries AS d WHERE d.id = gin.distillery_id) AS distillery_public_id,
+COALESCE((SELECT d.name FROM distilleries AS d WHERE d.id = gin.distillery_id), '') AS distillery,
+ARRAY(
+    SELECT b.name FROM gin_botanicals AS gb JOIN botanicals AS b ON b.id = gb.botanical_id
+    WHERE gb.gin_id = gin.id ORDER BY gb.position, b.name
+) AS botanicals, COALESCE((
+    SELECT json_agg(json_build_object('code', ft.code, 'label', ft.label) ORDER BY ft.code)
+    FROM gin_flavor_tags AS gft JOIN flavor_tags AS ft ON ft.id = gft.flavor_tag_id
+    WHERE gft.gin_id = gin.id
+), '[]'::json) AS flavor_tags,
+(
+    SELECT ROUND(AVG(t.rating), 2)::float8 FROM tasting_logs AS t
+    WHERE t.gin_id = gin.id AND t.status = 'approved'
+) AS average_rating, (
+    SELECT COUNT(*) FROM tasting_logs AS t
+    WHERE t.gin_id = gin.id AND t.status = 'approved'
+) AS tasting_count`
+
+// lockGins locks the selected gin rows, but not the rows of related tables read by
+// ginColumns, until the transaction ends.
+var lockGins = clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "gin"}}
+
 type gormRepository struct {
 	db *gorm.DB
 }
@@ -28,33 +141,711 @@ func NewRepository(db *gorm.DB) Repository {
 	return &gormRepository{db: db}
 }
 
-func (r *gormRepository) Search(ctx context.Context, filter SearchFilter) ([]Gin, error) {
-	var gins []Gin
+func (r *gormRepository) Search(ctx context.Context, filter SearchFilter) (SearchResult, error) {
+	var result SearchResult
+	err := r.withSearchSession(ctx, filter, func(db *gorm.DB) error {
+		var err error
+		result, err = search(db, filter)
+		return err
+	})
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	return result, nil
+}
 
-	tx := r.db.WithContext(ctx).Model(&Gin{})
+func (r *gormRepository) Export(ctx context.Context, filter SearchFilter, each func(Gin) error) error {
+	return r.withSearchSession(ctx, filter, func(db *gorm.DB) error {
+		rows, err := selectGins(db, filter).Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
 
-	trimmed := strings.TrimSpace(filter.Query)
-	if trimmed != "" {
-		needle := strings.ToLower(trimmed)
-		like := "%" + needle + "%"
-		tx = tx.Where(
-			`(LOWER(name) LIKE @like OR LOWER(country) LIKE @like OR LOWER(description) LIKE @like
-            OR EXISTS (SELECT 1 FROM unnest(botanicals) AS botanical WHERE LOWER(botanical) LIKE @like))`,
-			map[string]interface{}{"like": like},
+		for rows.Next() {
+			var gin Gin
+			if err := db.ScanRows(rows, &gin); err != nil {
+				return err
+			}
+			if err := each(gin); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	})
+}
+
+// withSearchSession runs fn on a session suitable for filter. Fuzzy searches need a
+// transaction to scope the similarity threshold.
+func (r *gormRepository) withSearchSession(ctx context.Context, filter SearchFilter, fn func(db *gorm.DB) error) error {
+	if !filter.Fuzzy {
+		return fn(r.db.WithContext(ctx))
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if err := setSimilarityThreshold(db, fuzzyMatchThreshold); err != nil {
+			return err
+		}
+		return fn(db)
+	})
+}
+
+// selectGins builds the filtered and ordered gin query shared by search and export, without
+// pagination.
+func selectGins(db *gorm.DB, filter SearchFilter) *gorm.DB {
+	tx := applyFilter(db.Model(&Gin{}), filter)
+
+	query := strings.TrimSpace(filter.Query)
+	switch {
+	case query != "" && filter.Fuzzy:
+		tx = tx.Select(
+			ginColumns+", GREATEST(ts_rank(search_vector, websearch_to_tsquery('english', ?)), word_similarity(?, LOWER(name))) AS score",
+			query, strings.ToLower(query),
 		)
+	case query != "":
+		tx = tx.Select(ginColumns+", ts_rank(search_vector, websearch_to_tsquery('english', ?)) AS score", query)
+	default:
+		tx = tx.Select(ginColumns)
+	}
+
+	if order := filter.sortOrder(); order == SortRelevance {
+		tx = tx.Order("score DESC, name ASC, id ASC")
+	} else {
+		tx = tx.Order(sortSpecs[order].orderClause())
+	}
+
+	return tx
+}
+
+func search(db *gorm.DB, filter SearchFilter) (SearchResult, error) {
+	var gins []Gin
+	var total int64
+
+	if err := applyFilter(db.Model(&Gin{}), filter).Count(&total).Error; err != nil {
+		return SearchResult{}, err
+	}
+
+	tx := selectGins(db, filter)
+
+	order := filter.sortOrder()
+	if order != SortRelevance && filter.Cursor != "" {
+		spec := sortSpecs[order]
+		after, err := decodeCursor(filter.Cursor, order)
+		if err != nil {
+			return SearchResult{}, err
+		}
+		value, _ := spec.parseValue(after.Value)
+		tx = tx.Where(spec.keysetClause(), value, after.ID)
 	}
 
 	if filter.Limit > 0 {
-		tx = tx.Limit(filter.Limit)
+		// Fetch one extra row to learn whether another page follows.
+		tx = tx.Limit(filter.Limit + 1)
 	}
 
 	if filter.Offset > 0 {
 		tx = tx.Offset(filter.Offset)
 	}
 
-	if err := tx.Order("name ASC").Find(&gins).Error; err != nil {
+	if err := tx.Find(&gins).Error; err != nil {
+		return SearchResult{}, err
+	}
+
+	result := SearchResult{Total: total}
+	if filter.Limit > 0 && len(gins) > filter.Limit {
+		gins = gins[:filter.Limit]
+		result.HasMore = true
+		if order != SortRelevance {
+			result.NextCursor = encodeCursor(order, gins[len(gins)-1])
+		}
+	}
+	result.Gins = gins
+
+	return result, nil
+}
+
+func (r *gormRepository) SimilarNames(ctx context.Context, query string, limit int) ([]string, error) {
+	needle := strings.ToLower(strings.TrimSpace(query))
+	if needle == "" {
+		return nil, nil
+	}
+
+	var names []string
+	err := r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if err := setSimilarityThreshold(db, suggestionThreshold); err != nil {
+			return err
+		}
+
+		return db.Model(&Gin{}).
+			Where("status = ?", string(StatusPublished)).
+			Where("? <% LOWER(name)", needle).
+			Order(clause.OrderBy{Expression: clause.Expr{SQL: "word_similarity(?, LOWER(name)) DESC, name ASC", Vars: []interface{}{needle}}}).
+			Limit(limit).
+			Pluck("name", &names).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// suggestQuery collects prefix completions for names, countries and botanicals of published,
+// non-deleted gins. Names rank ahead of countries and botanicals, and shorter completions rank ahead of
+// longer ones.
+const suggestQuery = `
+SELECT value, kind FROM (
+    (SELECT DISTINCT name AS value, 'name' AS kind, 0 AS priority
+        FROM gin WHERE status = @status AND deleted_at IS NULL AND LOWER(name) LIKE @prefix ESCAPE '\' LIMIT @limit)
+    UNION ALL
+    (SELECT DISTINCT country, 'country', 1
+        FROM gin WHERE status = @status AND deleted_at IS NULL AND LOWER(country) LIKE @prefix ESCAPE '\' LIMIT @limit)
+    UNION ALL
+    (SELECT name, 'botanical', 2
+        FROM botanicals AS b WHERE LOWER(b.name) LIKE @prefix ESCAPE '\'
+        AND EXISTS (
+            SELECT 1 FROM gin_botanicals AS gb JOIN gin AS g ON g.id = gb.gin_id
+            WHERE gb.botanical_id = b.id AND g.status = @status AND g.deleted_at IS NULL
+        ) LIMIT @limit)
+) AS suggestions
+ORDER BY priority, length(value), value
+LIMIT @limit`
+
+func (r *gormRepository) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	needle := strings.ToLower(strings.TrimSpace(prefix))
+	if needle == "" {
+		return nil, nil
+	}
+
+	var suggestions []Suggestion
+	err := r.db.WithContext(ctx).Raw(suggestQuery, map[string]interface{}{
+		"prefix": database.EscapeLike(needle) + "%",
+		"limit":  limit,
+		"status": string(StatusPublished),
+	}).Scan(&suggestions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// setSimilarityThreshold scopes pg_trgm's word similarity threshold, which drives the
+// index-backed <% operator, to the current transaction.
+func setSimilarityThreshold(db *gorm.DB, threshold float64) error {
+	return db.Exec(
+		"SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)",
+		strconv.FormatFloat(threshold, 'f', -1, 64),
+	).Error
+}
+
+// applyFilter adds the WHERE clauses described by filter to tx. All filters are combined with AND.
+func applyFilter(tx *gorm.DB, filter SearchFilter) *gorm.DB {
+	statuses := []string{string(StatusPublished)}
+	if len(filter.Statuses) > 0 {
+		statuses = make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+	}
+	tx = tx.Where("status IN ?", statuses)
+
+	if query := strings.TrimSpace(filter.Query); query != "" {
+		if filter.Fuzzy {
+			tx = tx.Where(
+				"(search_vector @@ websearch_to_tsquery('english', ?) OR ? <% LOWER(name))",
+				query, strings.ToLower(query),
+			)
+		} else {
+			tx = tx.Where("search_vector @@ websearch_to_tsquery('english', ?)", query)
+		}
+	}
+
+	if name := strings.TrimSpace(filter.Name); name != "" {
+		tx = tx.Where(`LOWER(name) LIKE ? ESCAPE '\'`, "%"+database.EscapeLike(strings.ToLower(name))+"%")
+	}
+
+	if distillery := strings.TrimSpace(filter.Distillery); distillery != "" {
+		tx = tx.Where(
+			"EXISTS (SELECT 1 FROM distilleries AS d WHERE d.id = gin.distillery_id AND LOWER(d.name) = ?)",
+			strings.ToLower(distillery),
+		)
+	}
+
+	if filter.DistilleryID != nil {
+		tx = tx.Where("gin.distillery_id = (SELECT d.id FROM distilleries AS d WHERE d.public_id = ?)", *filter.DistilleryID)
+	}
+
+	if country := strings.TrimSpace(filter.Country); country != "" {
+		tx = tx.Where("LOWER(country) = ?", strings.ToLower(country))
+	}
+
+	if region := strings.TrimSpace(filter.Region); region != "" {
+		tx = tx.Where("LOWER(region) = ?", strings.ToLower(region))
+	}
+
+	if filter.ABVMin != nil {
+		tx = tx.Where("abv >= ?", *filter.ABVMin)
+	}
+
+	if filter.ABVMax != nil {
+		tx = tx.Where("abv <= ?", *filter.ABVMax)
+	}
+
+	botanicals := make([]string, 0, len(filter.Botanicals))
+	for _, botanical := range filter.Botanicals {
+		if trimmed := strings.TrimSpace(botanical); trimmed != "" {
+			botanicals = append(botanicals, strings.ToLower(trimmed))
+		}
+	}
+	if len(botanicals) > 0 {
+		const botanicalExists = `EXISTS (SELECT 1 FROM gin_botanicals AS gb JOIN botanicals AS b ON b.id = gb.botanical_id
+            WHERE gb.gin_id = gin.id AND LOWER(b.name) IN ?)`
+		if filter.BotanicalMatch == BotanicalMatchAll {
+			for _, botanical := range botanicals {
+				tx = tx.Where(botanicalExists, []string{botanical})
+			}
+		} else {
+			tx = tx.Where(botanicalExists, botanicals)
+		}
+	}
+
+	for _, code := range normalizeFlavorTagCodes(filter.FlavorTags) {
+		tx = tx.Where(`EXISTS (SELECT 1 FROM gin_flavor_tags AS gft JOIN flavor_tags AS ft ON ft.id = gft.flavor_tag_id
+            WHERE gft.gin_id = gin.id AND ft.code = ?)`, code)
+	}
+
+	return tx
+}
+
+func (r *gormRepository) GetByID(ctx context.Context, id uuid.UUID) (*Gin, error) {
+	var gin Gin
+
+	err := r.db.WithContext(ctx).Select(ginColumns).Where("public_id = ?", id).Take(&gin).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &gin, nil
+}
+
+func (r *gormRepository) Create(ctx context.Context, gin *Gin, revision *Revision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := resolveDistillery(tx, gin); err != nil {
+			return err
+		}
+		if err := tx.Create(gin).Error; err != nil {
+			return err
+		}
+		if err := syncBotanicals(tx, gin); err != nil {
+			return err
+		}
+		if err := syncFlavorTags(tx, gin); err != nil {
+			return err
+		}
+		return recordRevision(tx, gin, revision)
+	})
+}
+
+// Modify locks the gin, lets modify change it, and stores the result together with revision
+// in one transaction. The row lock serialises concurrent writers, so each one starts from
+// the state left by the previous write and its revision diffs against that state.
+func (r *gormRepository) Modify(ctx context.Context, id uuid.UUID, revision *Revision, modify func(*Gin) error) (*Gin, error) {
+	var gin Gin
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Select(ginColumns).
+			Clauses(lockGins).
+			Where("public_id = ?", id).
+			Take(&gin).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		previous := snapshotOf(&gin)
+		if err := modify(&gin); err != nil {
+			return err
+		}
+		revision.previous = &previous
+
+		if err := resolveDistillery(tx, &gin); err != nil {
+			return err
+		}
+		err = tx.Model(&gin).
+			Select(
+				"name", "distillery_id", "country", "region", "abv",
+				"description", "tasting_notes", "image_url", "status", "updated_at",
+			).
+			Updates(&gin).Error
+		if err != nil {
+			return err
+		}
+		if err := syncBotanicals(tx, &gin); err != nil {
+			return err
+		}
+		if err := syncFlavorTags(tx, &gin); err != nil {
+			return err
+		}
+		return recordRevision(tx, &gin, revision)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &gin, nil
+}
+
+// resolveDistillery maps gin.DistilleryPublicID onto the internal distillery_id foreign key
+// and fills in the distillery name for the response.
+func resolveDistillery(tx *gorm.DB, gin *Gin) error {
+	if gin.DistilleryPublicID == nil {
+		gin.DistilleryID = nil
+		gin.Distillery = ""
+		return nil
+	}
+
+	var distillery struct {
+		ID   uint
+		Name string
+	}
+	err := tx.Table("distilleries").
+		Select("id, name").
+		Where("public_id = ?", *gin.DistilleryPublicID).
+		Take(&distillery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: unknown distillery_id", ErrInvalidGin)
+		}
+		return err
+	}
+
+	gin.DistilleryID = &distillery.ID
+	gin.Distillery = distillery.Name
+	return nil
+}
+
+// syncBotanicals replaces the gin's botanical links with gin.Botanicals, creating master
+// rows for unseen botanicals. gin.Botanicals is rewritten with the canonical master names.
+func syncBotanicals(tx *gorm.DB, gin *Gin) error {
+	if err := tx.Exec("DELETE FROM gin_botanicals WHERE gin_id = ?", gin.ID).Error; err != nil {
+		return err
+	}
+	if len(gin.Botanicals) == 0 {
+		gin.Botanicals = pq.StringArray{}
+		return nil
+	}
+
+	for _, name := range gin.Botanicals {
+		err := tx.Exec("INSERT INTO botanicals (name) VALUES (?) ON CONFLICT ((LOWER(name))) DO NOTHING", name).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, len(gin.Botanicals))
+	for i, name := range gin.Botanicals {
+		keys[i] = strings.ToLower(name)
+	}
+
+	var masters []Botanical
+	if err := tx.Where("LOWER(name) IN ?", keys).Find(&masters).Error; err != nil {
+		return err
+	}
+	byKey := make(map[string]Botanical, len(masters))
+	for _, master := range masters {
+		byKey[strings.ToLower(master.Name)] = master
+	}
+
+	canonical := make(pq.StringArray, 0, len(keys))
+	for position, key := range keys {
+		master, ok := byKey[key]
+		if !ok {
+			continue
+		}
+		err := tx.Exec(
+			"INSERT INTO gin_botanicals (gin_id, botanical_id, position) VALUES (?, ?, ?)",
+			gin.ID, master.ID, position,
+		).Error
+		if err != nil {
+			return err
+		}
+		canonical = append(canonical, master.Name)
+	}
+	gin.Botanicals = canonical
+
+	return nil
+}
+
+// canonicalBotanicals replaces names with the spelling stored in the botanicals master
+// table, matching case-insensitively like syncBotanicals. Names without a master row are
+// kept as given, which is also how syncBotanicals would create them.
+func canonicalBotanicals(tx *gorm.DB, names pq.StringArray) (pq.StringArray, error) {
+	if len(names) == 0 {
+		return names, nil
+	}
+
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = strings.ToLower(name)
+	}
+
+	var masters []Botanical
+	if err := tx.Where("LOWER(name) IN ?", keys).Find(&masters).Error; err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]string, len(masters))
+	for _, master := range masters {
+		byKey[strings.ToLower(master.Name)] = master.Name
+	}
+
+	canonical := make(pq.StringArray, len(names))
+	for i, name := range names {
+		if master, ok := byKey[keys[i]]; ok {
+			name = master
+		}
+		canonical[i] = name
+	}
+	return canonical, nil
+}
+
+// syncFlavorTags replaces the gin's flavor tag links with the codes in gin.FlavorTags. Tags
+// are a curated taxonomy, so unknown codes are rejected rather than created.
+func syncFlavorTags(tx *gorm.DB, gin *Gin) error {
+	if err := tx.Exec("DELETE FROM gin_flavor_tags WHERE gin_id = ?", gin.ID).Error; err != nil {
+		return err
+	}
+
+	codes := make([]string, len(gin.FlavorTags))
+	for i, tag := range gin.FlavorTags {
+		codes[i] = tag.Code
+	}
+	codes = normalizeFlavorTagCodes(codes)
+	if len(codes) == 0 {
+		gin.FlavorTags = FlavorTags{}
+		return nil
+	}
+
+	var tags []FlavorTag
+	if err := tx.Where("code IN ?", codes).Order("code ASC").Find(&tags).Error; err != nil {
+		return err
+	}
+	if len(tags) != len(codes) {
+		known := make(map[string]struct{}, len(tags))
+		for _, tag := range tags {
+			known[tag.Code] = struct{}{}
+		}
+		var unknown []string
+		for _, code := range codes {
+			if _, ok := known[code]; !ok {
+				unknown = append(unknown, code)
+			}
+		}
+		return fmt.Errorf("%w: unknown flavor tags: %s", ErrInvalidGin, strings.Join(unknown, ", "))
+	}
+
+	for _, tag := range tags {
+		err := tx.Exec("INSERT INTO gin_flavor_tags (gin_id, flavor_tag_id) VALUES (?, ?)", gin.ID, tag.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	gin.FlavorTags = tags
+
+	return nil
+}
+
+func (r *gormRepository) Delete(ctx context.Context, id uuid.UUID, revision *Revision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("public_id = ?", id).Delete(&Gin{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return recordRevisionOf(tx, id, revision)
+	})
+}
+
+func (r *gormRepository) ListDeleted(ctx context.Context, limit, offset int) ([]Gin, int64, error) {
+	var gins []Gin
+	var total int64
+
+	deleted := func() *gorm.DB {
+		return r.db.WithContext(ctx).Unscoped().Model(&Gin{}).Where("deleted_at IS NOT NULL")
+	}
+
+	if err := deleted().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tx := deleted().Select(ginColumns)
+
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+
+	if offset > 0 {
+		tx = tx.Offset(offset)
+	}
+
+	if err := tx.Order("deleted_at DESC, id DESC").Find(&gins).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return gins, total, nil
+}
+
+func (r *gormRepository) Restore(ctx context.Context, id uuid.UUID, revision *Revision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&Gin{}).
+			Where("public_id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return recordRevisionOf(tx, id, revision)
+	})
+}
+
+// recordRevisionOf loads the gin's current state, including soft-deleted rows, and records
+// revision for it.
+func recordRevisionOf(tx *gorm.DB, id uuid.UUID, revision *Revision) error {
+	var gin Gin
+	if err := tx.Unscoped().Select(ginColumns).Where("public_id = ?", id).Take(&gin).Error; err != nil {
+		return err
+	}
+	return recordRevision(tx, &gin, revision)
+}
+
+func (r *gormRepository) ListRevisions(ctx context.Context, id uuid.UUID) ([]Revision, error) {
+	var revisions []Revision
+	err := r.db.WithContext(ctx).Where("gin_public_id = ?", id).Order("revision DESC").Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		if err := r.requireKnownGin(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return revisions, nil
+}
+
+func (r *gormRepository) GetRevision(ctx context.Context, id uuid.UUID, number int) (*Revision, error) {
+	var revision Revision
+	err := r.db.WithContext(ctx).Where("gin_public_id = ? AND revision = ?", id, number).Take(&revision).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err := r.requireKnownGin(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrRevisionNotFound
+	}
+
+	return &revision, nil
+}
+
+// requireKnownGin returns ErrNotFound unless id names a gin, soft-deleted gins included, or
+// a purged gin whose history remains as its tombstone.
+func (r *gormRepository) requireKnownGin(ctx context.Context, id uuid.UUID) error {
+	var known bool
+	err := r.db.WithContext(ctx).Raw(
+		"SELECT EXISTS (SELECT 1 FROM gin WHERE public_id = ?) OR EXISTS (SELECT 1 FROM gin_revisions WHERE gin_public_id = ?)",
+		id, id,
+	).Scan(&known).Error
+	if err != nil {
+		return err
+	}
+	if !known {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Purge removes gins soft-deleted before deletedBefore, recording revision as the final
+// entry of each gin's history. The history itself is kept as the purged gin's tombstone.
+// Gins that still have tastings are skipped, since removing them would lose user content.
+func (r *gormRepository) Purge(ctx context.Context, deletedBefore time.Time, revision *Revision) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gins []Gin
+		err := tx.Unscoped().
+			Select(ginColumns).
+			Where("deleted_at < ?", deletedBefore).
+			Where("NOT EXISTS (SELECT 1 FROM tasting_logs AS t WHERE t.gin_id = gin.id)").
+			Clauses(lockGins).
+			Find(&gins).Error
+		if err != nil || len(gins) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(gins))
+		for i := range gins {
+			tombstone := *revision
+			if err := recordRevision(tx, &gins[i], &tombstone); err != nil {
+				return err
+			}
+			ids[i] = gins[i].ID
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&Gin{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+func (r *gormRepository) ListBotanicals(ctx context.Context) ([]BotanicalCount, error) {
+	var botanicals []BotanicalCount
+
+	err := r.db.WithContext(ctx).
+		Table("botanicals AS b").
+		Select("b.public_id, b.name, COUNT(g.id) AS gin_count").
+		Joins("LEFT JOIN gin_botanicals AS gb ON gb.botanical_id = b.id").
+		Joins("LEFT JOIN gin AS g ON g.id = gb.gin_id AND g.status = ? AND g.deleted_at IS NULL", string(StatusPublished)).
+		Group("b.id").
+		Order("b.name ASC").
+		Scan(&botanicals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return botanicals, nil
+}
+
+func (r *gormRepository) ListFlavorTags(ctx context.Context) ([]FlavorTagCount, error) {
+	var tags []FlavorTagCount
+
+	err := r.db.WithContext(ctx).
+		Table("flavor_tags AS ft").
+		Select("ft.code, ft.label, COUNT(g.id) AS gin_count").
+		Joins("LEFT JOIN gin_flavor_tags AS gft ON gft.flavor_tag_id = ft.id").
+		Joins("LEFT JOIN gin AS g ON g.id = gft.gin_id AND g.status = ? AND g.deleted_at IS NULL", string(StatusPublished)).
+		Group("ft.id").
+		Order("ft.code ASC").
+		Scan(&tags).Error
+	if err != nil {
 		return nil, err
 	}
 
-	return gins, nil
+	return tags, nil
 }
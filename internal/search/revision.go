@@ -0,0 +1,206 @@
+package search
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RevisionAction names the kind of write that produced a revision.
+type RevisionAction string
+
+const (
+	RevisionCreate  RevisionAction = "create"
+	RevisionUpdate  RevisionAction = "update"
+	RevisionArchive RevisionAction = "archive"
+	RevisionDelete  RevisionAction = "delete"
+	RevisionRestore RevisionAction = "restore"
+	RevisionRevert  RevisionAction = "revert"
+	RevisionImport  RevisionAction = "import"
+	RevisionPurge   RevisionAction = "purge"
+)
+
+// Revision is one entry in a gin's audit history, recorded in the same transaction as the
+// write it describes. Revisions outlive a purged gin: GinID is then cleared and GinPublicID
+// alone identifies the gin.
+type Revision struct {
+	ID          uint           `json:"-" gorm:"column:id;primaryKey"`
+	PublicID    uuid.UUID      `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	GinID       *uint          `json:"-" gorm:"column:gin_id"`
+	GinPublicID uuid.UUID      `json:"-" gorm:"column:gin_public_id;type:uuid;not null"`
+	Number      int            `json:"revision" gorm:"column:revision;not null"`
+	Action      RevisionAction `json:"action" gorm:"column:action;type:varchar(16);not null"`
+	Snapshot    Snapshot       `json:"snapshot" gorm:"column:snapshot;type:jsonb;not null"`
+	Diff        Diff           `json:"diff" gorm:"column:diff;type:jsonb;not null"`
+	Actor       string         `json:"actor" gorm:"column:actor;type:varchar(255);not null"`
+	RequestID   string         `json:"request_id" gorm:"column:request_id;type:varchar(255);not null"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"column:created_at"`
+
+	// previous is the state the write started from. Writes that do not change attributes,
+	// such as deletes, leave it nil and record an empty diff.
+	previous *Snapshot
+}
+
+// TableName specifies the PostgreSQL table name for gin revisions.
+func (Revision) TableName() string {
+	return "gin_revisions"
+}
+
+// capture records the gin's state after the write, and the difference from the previous state.
+func (r *Revision) capture(gin *Gin) error {
+	r.GinID = &gin.ID
+	r.GinPublicID = gin.PublicID
+	r.Snapshot = snapshotOf(gin)
+	r.Diff = Diff{}
+	if r.previous == nil {
+		return nil
+	}
+
+	diff, err := diffSnapshots(*r.previous, r.Snapshot)
+	if err != nil {
+		return err
+	}
+	r.Diff = diff
+	return nil
+}
+
+// recordRevision stores revision for gin with the next revision number of that gin. It must
+// run in the transaction that wrote the gin, whose row lock serialises numbering.
+func recordRevision(tx *gorm.DB, gin *Gin, revision *Revision) error {
+	if err := revision.capture(gin); err != nil {
+		return err
+	}
+
+	err := tx.Model(&Revision{}).
+		Select("COALESCE(MAX(revision), 0) + 1").
+		Where("gin_public_id = ?", gin.PublicID).
+		Scan(&revision.Number).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(revision).Error
+}
+
+// Snapshot captures the writable attributes of a gin. It shares GinInput's JSON layout so a
+// snapshot can be replayed as a full update when reverting.
+type Snapshot GinInput
+
+func snapshotOf(gin *Gin) Snapshot {
+	botanicals := make([]string, len(gin.Botanicals))
+	copy(botanicals, gin.Botanicals)
+
+	flavorTags := make([]string, len(gin.FlavorTags))
+	for i, tag := range gin.FlavorTags {
+		flavorTags[i] = tag.Code
+	}
+
+	return Snapshot{
+		Name:         gin.Name,
+		DistilleryID: gin.DistilleryPublicID,
+		Country:      gin.Country,
+		Region:       gin.Region,
+		ABV:          gin.ABV,
+		Botanicals:   botanicals,
+		FlavorTags:   flavorTags,
+		Description:  gin.Description,
+		TastingNotes: gin.TastingNotes,
+		ImageURL:     gin.ImageURL,
+		Status:       gin.Status,
+	}
+}
+
+// Value implements driver.Valuer.
+func (s Snapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements sql.Scanner.
+func (s *Snapshot) Scan(value interface{}) error {
+	raw, err := jsonBytes(value)
+	if err != nil {
+		return fmt.Errorf("unsupported snapshot value: %w", err)
+	}
+	return json.Unmarshal(raw, s)
+}
+
+// Change is the before and after JSON value of one snapshot attribute.
+type Change struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// Diff maps the JSON names of changed attributes to their change.
+type Diff map[string]Change
+
+// diffSnapshots compares two snapshots attribute by attribute on their JSON encoding.
+func diffSnapshots(from, to Snapshot) (Diff, error) {
+	before, err := snapshotFields(from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := snapshotFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := Diff{}
+	for field, value := range after {
+		if previous := before[field]; !bytes.Equal(previous, value) {
+			diff[field] = Change{From: previous, To: value}
+		}
+	}
+	return diff, nil
+}
+
+func snapshotFields(snapshot Snapshot) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// Value implements driver.Valuer.
+func (d Diff) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(d)
+}
+
+// Scan implements sql.Scanner.
+func (d *Diff) Scan(value interface{}) error {
+	raw, err := jsonBytes(value)
+	if err != nil {
+		return fmt.Errorf("unsupported diff value: %w", err)
+	}
+
+	diff := Diff{}
+	if err := json.Unmarshal(raw, &diff); err != nil {
+		return err
+	}
+	*d = diff
+	return nil
+}
+
+func jsonBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, fmt.Errorf("unexpected type %T", value)
+	}
+}
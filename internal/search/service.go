@@ -3,6 +3,26 @@ package search
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gin-mania-backend/pkg/requestctx"
+)
+
+const (
+	// DefaultSearchLimit is the page size used when a search does not specify a limit.
+	DefaultSearchLimit = 20
+	// MaxSearchLimit bounds the page size of searches so that every listing is paginated.
+	MaxSearchLimit = 100
+	// maxSuggestions caps the "did you mean" names returned for queries without matches.
+	maxSuggestions = 5
+	// DefaultSuggestLimit is the number of typeahead completions returned when no limit is given.
+	DefaultSuggestLimit = 8
+	// MaxSuggestLimit bounds typeahead responses to keep them small and fast.
+	MaxSuggestLimit = 20
 )
 
 var (
@@ -10,6 +30,19 @@ var (
 	ErrRepositoryNotConfigured = errors.New("search repository not configured")
 	// ErrInvalidPagination is returned when the requested pagination parameters are negative.
 	ErrInvalidPagination = errors.New("invalid pagination parameters")
+	// ErrInvalidFilter is returned when the requested search filters are malformed.
+	ErrInvalidFilter = errors.New("invalid search filter")
+	// ErrNotFound is returned when the requested gin does not exist.
+	ErrNotFound = errors.New("gin not found")
+	// ErrInvalidGin is returned when a create or update request fails validation.
+	ErrInvalidGin = errors.New("invalid gin")
+	// ErrInvalidStatusTransition is returned when a write would move a gin to a publication
+	// status that cannot follow its current one.
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	// ErrRevisionNotFound is returned when the requested revision does not exist for a gin.
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrInvalidRetention is returned when a purge is requested without a positive retention window.
+	ErrInvalidRetention = errors.New("retention must be positive")
 )
 
 // Service provides search capabilities backed by a repository implementation.
@@ -22,20 +55,315 @@ func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
-// Search retrieves gins that satisfy the provided filter parameters.
-func (s *Service) Search(ctx context.Context, filter SearchFilter) ([]Gin, error) {
+// Search retrieves one page of gins that satisfy the provided filter parameters. Searches
+// without a limit return DefaultSearchLimit gins.
+func (s *Service) Search(ctx context.Context, filter SearchFilter) (SearchResult, error) {
 	if s.repo == nil {
-		return nil, ErrRepositoryNotConfigured
+		return SearchResult{}, ErrRepositoryNotConfigured
+	}
+
+	if err := validateFilter(filter); err != nil {
+		return SearchResult{}, err
+	}
+	if filter.Limit > MaxSearchLimit {
+		return SearchResult{}, fmt.Errorf("%w: limit must be at most %d", ErrInvalidPagination, MaxSearchLimit)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultSearchLimit
+	}
+
+	result, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	if result.Total == 0 && !filter.Fuzzy && strings.TrimSpace(filter.Query) != "" {
+		suggestions, err := s.repo.SimilarNames(ctx, filter.Query, maxSuggestions)
+		if err != nil {
+			return SearchResult{}, err
+		}
+		result.Suggestions = suggestions
+	}
+
+	return result, nil
+}
+
+// Export streams every gin matching the filter to each, in the filter's sort order. Rows are
+// read from a database cursor, so memory use does not grow with the catalogue. Pagination
+// fields of the filter are ignored. Iteration stops at the first error returned by each.
+func (s *Service) Export(ctx context.Context, filter SearchFilter, each func(Gin) error) error {
+	if s.repo == nil {
+		return ErrRepositoryNotConfigured
+	}
+
+	filter.Limit, filter.Offset, filter.Cursor = 0, 0, ""
+	if err := validateFilter(filter); err != nil {
+		return err
 	}
 
+	return s.repo.Export(ctx, filter, each)
+}
+
+func validateFilter(filter SearchFilter) error {
 	if filter.Limit < 0 || filter.Offset < 0 {
+		return ErrInvalidPagination
+	}
+
+	order := filter.sortOrder()
+	if _, err := ParseSortOrder(string(order)); err != nil {
+		return err
+	}
+	if order == SortRelevance && strings.TrimSpace(filter.Query) == "" {
+		return fmt.Errorf("%w: relevance sort requires a query", ErrInvalidFilter)
+	}
+
+	if filter.Cursor != "" {
+		if filter.Offset > 0 {
+			return fmt.Errorf("%w: cursor and offset cannot be combined", ErrInvalidPagination)
+		}
+		if order == SortRelevance {
+			return fmt.Errorf("%w: cursor pagination is not supported for relevance sort", ErrInvalidPagination)
+		}
+		if _, err := decodeCursor(filter.Cursor, order); err != nil {
+			return err
+		}
+	}
+
+	for _, status := range filter.Statuses {
+		if _, err := ParseStatus(string(status)); err != nil {
+			return err
+		}
+	}
+
+	switch filter.BotanicalMatch {
+	case "", BotanicalMatchAny, BotanicalMatchAll:
+	default:
+		return ErrInvalidFilter
+	}
+
+	if filter.ABVMin != nil && filter.ABVMax != nil && *filter.ABVMin > *filter.ABVMax {
+		return fmt.Errorf("%w: abv_min must not exceed abv_max", ErrInvalidFilter)
+	}
+
+	return nil
+}
+
+// Suggest returns ranked typeahead completions for names, countries and botanicals that
+// start with prefix.
+func (s *Service) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	if limit == 0 {
+		limit = DefaultSuggestLimit
+	}
+	if limit < 0 || limit > MaxSuggestLimit {
 		return nil, ErrInvalidPagination
 	}
 
-	return s.repo.Search(ctx, filter)
+	return s.repo.Suggest(ctx, prefix, limit)
+}
+
+// Get retrieves a single gin by its public identifier regardless of its publication status.
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*Gin, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetPublished retrieves a single published gin. Drafts and archived gins are reported as
+// not found so that their existence is not disclosed publicly.
+func (s *Service) GetPublished(ctx context.Context, id uuid.UUID) (*Gin, error) {
+	gin, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if gin.Status != StatusPublished {
+		return nil, ErrNotFound
+	}
+
+	return gin, nil
+}
+
+// Create validates the input and persists a new gin.
+func (s *Service) Create(ctx context.Context, input GinInput) (*Gin, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	gin := &Gin{PublicID: uuid.New(), Status: StatusDraft}
+	input.apply(gin)
+	if err := validateGin(gin); err != nil {
+		return nil, err
+	}
+	if err := checkTransition(StatusDraft, gin.Status); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, gin, newRevision(ctx, RevisionCreate, &Snapshot{})); err != nil {
+		return nil, err
+	}
+	return gin, nil
+}
+
+// Update replaces all writable attributes of an existing gin.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, input GinInput) (*Gin, error) {
+	return s.modify(ctx, id, RevisionUpdate, input.apply)
+}
+
+// Patch applies a partial update to an existing gin.
+func (s *Service) Patch(ctx context.Context, id uuid.UUID, patch GinPatch) (*Gin, error) {
+	return s.modify(ctx, id, RevisionUpdate, patch.apply)
+}
+
+// Archive retires a gin from the public catalogue while keeping it visible to administrators.
+// Unlike Delete, archiving cannot be undone.
+func (s *Service) Archive(ctx context.Context, id uuid.UUID) (*Gin, error) {
+	return s.modify(ctx, id, RevisionArchive, func(g *Gin) {
+		g.Status = StatusArchived
+	})
+}
+
+// Delete soft-deletes a gin, hiding it from every listing until it is restored or purged.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	if s.repo == nil {
+		return ErrRepositoryNotConfigured
+	}
+
+	return s.repo.Delete(ctx, id, newRevision(ctx, RevisionDelete, nil))
+}
+
+// ListDeleted returns soft-deleted gins, most recently deleted first, along with their total.
+func (s *Service) ListDeleted(ctx context.Context, limit, offset int) ([]DeletedGin, int64, error) {
+	if s.repo == nil {
+		return nil, 0, ErrRepositoryNotConfigured
+	}
+
+	if limit < 0 || offset < 0 {
+		return nil, 0, ErrInvalidPagination
+	}
+
+	gins, total, err := s.repo.ListDeleted(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	deleted := make([]DeletedGin, len(gins))
+	for i, gin := range gins {
+		deleted[i] = DeletedGin{Gin: gin, DeletedAt: gin.DeletedAt.Time}
+	}
+	return deleted, total, nil
+}
+
+// Restore brings a soft-deleted gin back with the publication status it had when deleted.
+func (s *Service) Restore(ctx context.Context, id uuid.UUID) (*Gin, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	if err := s.repo.Restore(ctx, id, newRevision(ctx, RevisionRestore, nil)); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// Purge permanently removes gins that were soft-deleted longer ago than retention and
+// reports how many rows were removed. Gins with tastings are kept, and the revision history
+// of purged gins remains available.
+func (s *Service) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	if s.repo == nil {
+		return 0, ErrRepositoryNotConfigured
+	}
+
+	if retention <= 0 {
+		return 0, ErrInvalidRetention
+	}
+
+	return s.repo.Purge(ctx, time.Now().Add(-retention), newRevision(ctx, RevisionPurge, nil))
+}
+
+// History returns the audit trail of a gin, newest revision first. It remains available
+// while the gin is soft-deleted and after it has been purged.
+func (s *Service) History(ctx context.Context, id uuid.UUID) ([]Revision, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	return s.repo.ListRevisions(ctx, id)
+}
+
+// Revert restores the attributes recorded in an earlier revision of a gin. The publication
+// status is left unchanged so that reverting never bypasses the publication workflow.
+func (s *Service) Revert(ctx context.Context, id uuid.UUID, number int) (*Gin, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	revision, err := s.repo.GetRevision(ctx, id, number)
+	if err != nil {
+		return nil, err
+	}
+
+	input := GinInput(revision.Snapshot)
+	input.Status = ""
+	return s.modify(ctx, id, RevisionRevert, input.apply)
+}
+
+// modify applies mutate to the current state of a gin and stores the result if it is valid.
+// The repository holds the gin's row lock from reading it until the write is recorded, so
+// concurrent writes cannot overwrite each other or validate a stale status.
+func (s *Service) modify(ctx context.Context, id uuid.UUID, action RevisionAction, mutate func(*Gin)) (*Gin, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	return s.repo.Modify(ctx, id, newRevision(ctx, action, nil), func(gin *Gin) error {
+		current := gin.Status
+		mutate(gin)
+		if err := validateGin(gin); err != nil {
+			return err
+		}
+		return checkTransition(current, gin.Status)
+	})
+}
+
+// newRevision prepares the audit record for a write, attributing it to the actor and
+// request carried by ctx. The actor is empty only for anonymous writes, which are possible
+// while authentication is disabled and no X-Actor header is trusted.
+func newRevision(ctx context.Context, action RevisionAction, previous *Snapshot) *Revision {
+	return &Revision{
+		Action:    action,
+		Actor:     requestctx.Actor(ctx),
+		RequestID: requestctx.RequestID(ctx),
+		previous:  previous,
+	}
+}
+
+// ListBotanicals returns every botanical in the master table with the number of published
+// gins using it.
+func (s *Service) ListBotanicals(ctx context.Context) ([]BotanicalCount, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	return s.repo.ListBotanicals(ctx)
+}
+
+// ListFlavorTags returns the flavor taxonomy with the number of published gins carrying
+// each tag.
+func (s *Service) ListFlavorTags(ctx context.Context) ([]FlavorTagCount, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	return s.repo.ListFlavorTags(ctx)
 }
 
-// SearchByQuery is a convenience wrapper for simple query-driven searches without pagination.
-func (s *Service) SearchByQuery(ctx context.Context, query string) ([]Gin, error) {
+// SearchByQuery is a convenience wrapper for simple query-driven searches returning the
+// first page of results.
+func (s *Service) SearchByQuery(ctx context.Context, query string) (SearchResult, error) {
 	return s.Search(ctx, SearchFilter{Query: query})
 }
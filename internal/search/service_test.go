@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// stubRepository records the filters passed to Search and modifies a copy of current.
+// Other methods are not implemented.
+type stubRepository struct {
+	Repository
+	filters []SearchFilter
+	current Gin
+}
+
+func (s *stubRepository) Search(_ context.Context, filter SearchFilter) (SearchResult, error) {
+	s.filters = append(s.filters, filter)
+	return SearchResult{Total: 1}, nil
+}
+
+func (s *stubRepository) Modify(_ context.Context, _ uuid.UUID, _ *Revision, modify func(*Gin) error) (*Gin, error) {
+	gin := s.current
+	if err := modify(&gin); err != nil {
+		return nil, err
+	}
+	return &gin, nil
+}
+
+func TestModifyChecksTheStoredState(t *testing.T) {
+	repo := &stubRepository{current: Gin{Name: "Roku", Country: "Japan", Status: StatusArchived}}
+	service := NewService(repo)
+
+	published := StatusPublished
+	if _, err := service.Patch(context.Background(), uuid.New(), GinPatch{Status: &published}); !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Fatalf("publishing an archived gin: error = %v, want ErrInvalidStatusTransition", err)
+	}
+
+	empty := ""
+	if _, err := service.Patch(context.Background(), uuid.New(), GinPatch{Name: &empty}); !errors.Is(err, ErrInvalidGin) {
+		t.Fatalf("clearing the name: error = %v, want ErrInvalidGin", err)
+	}
+
+	region := "Kansai"
+	gin, err := service.Patch(context.Background(), uuid.New(), GinPatch{Region: &region})
+	if err != nil {
+		t.Fatalf("Patch returned error: %v", err)
+	}
+	if gin.Region != region || gin.Name != "Roku" || gin.Status != StatusArchived {
+		t.Fatalf("patched gin = %+v", gin)
+	}
+}
+
+func TestSearchPaginatesByDefault(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int
+		want    int
+		wantErr error
+	}{
+		{name: "no limit uses the default page size", limit: 0, want: DefaultSearchLimit},
+		{name: "explicit limit is kept", limit: 5, want: 5},
+		{name: "maximum limit", limit: MaxSearchLimit, want: MaxSearchLimit},
+		{name: "limit above the maximum", limit: MaxSearchLimit + 1, wantErr: ErrInvalidPagination},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepository{}
+			_, err := NewService(repo).Search(context.Background(), SearchFilter{Limit: tt.limit})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || len(repo.filters) != 0 {
+					t.Fatalf("error = %v after %d searches, want %v", err, len(repo.filters), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Search returned error: %v", err)
+			}
+			if len(repo.filters) != 1 || repo.filters[0].Limit != tt.want {
+				t.Fatalf("repository filters = %+v, want limit %d", repo.filters, tt.want)
+			}
+		})
+	}
+}
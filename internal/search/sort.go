@@ -0,0 +1,95 @@
+package search
+
+import (
+	"fmt"
+	"time"
+)
+
+// SortOrder names an allowed ordering for search results. Descending variants are
+// prefixed with "-".
+type SortOrder string
+
+const (
+	SortNameAsc       SortOrder = "name"
+	SortNameDesc      SortOrder = "-name"
+	SortCountryAsc    SortOrder = "country"
+	SortCountryDesc   SortOrder = "-country"
+	SortCreatedAtAsc  SortOrder = "created_at"
+	SortCreatedAtDesc SortOrder = "-created_at"
+	SortUpdatedAtAsc  SortOrder = "updated_at"
+	SortUpdatedAtDesc SortOrder = "-updated_at"
+	// SortRelevance ranks results by how closely they match the query; it requires a query.
+	SortRelevance SortOrder = "relevance"
+)
+
+// DefaultSortOrder is applied when a filter specifies neither an ordering nor a query.
+const DefaultSortOrder = SortNameAsc
+
+type sortSpec struct {
+	column    string
+	desc      bool
+	timestamp bool
+}
+
+// sortSpecs is the allow-list of column orderings; only these column names ever reach SQL.
+var sortSpecs = map[SortOrder]sortSpec{
+	SortNameAsc:       {column: "name"},
+	SortNameDesc:      {column: "name", desc: true},
+	SortCountryAsc:    {column: "country"},
+	SortCountryDesc:   {column: "country", desc: true},
+	SortCreatedAtAsc:  {column: "created_at", timestamp: true},
+	SortCreatedAtDesc: {column: "created_at", desc: true, timestamp: true},
+	SortUpdatedAtAsc:  {column: "updated_at", timestamp: true},
+	SortUpdatedAtDesc: {column: "updated_at", desc: true, timestamp: true},
+}
+
+// ParseSortOrder validates a client-supplied sort value against the allow-list.
+// An empty value resolves to DefaultSortOrder.
+func ParseSortOrder(value string) (SortOrder, error) {
+	if value == "" {
+		return DefaultSortOrder, nil
+	}
+
+	order := SortOrder(value)
+	if order == SortRelevance {
+		return order, nil
+	}
+	if _, ok := sortSpecs[order]; !ok {
+		return "", fmt.Errorf("%w: unsupported sort %q", ErrInvalidFilter, value)
+	}
+	return order, nil
+}
+
+func (s sortSpec) orderClause() string {
+	if s.desc {
+		return s.column + " DESC, id DESC"
+	}
+	return s.column + " ASC, id ASC"
+}
+
+func (s sortSpec) keysetClause() string {
+	if s.desc {
+		return "(" + s.column + ", id) < (?, ?)"
+	}
+	return "(" + s.column + ", id) > (?, ?)"
+}
+
+func (s sortSpec) value(g Gin) string {
+	switch s.column {
+	case "country":
+		return g.Country
+	case "created_at":
+		return g.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case "updated_at":
+		return g.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		return g.Name
+	}
+}
+
+func (s sortSpec) parseValue(raw string) (interface{}, error) {
+	if !s.timestamp {
+		return raw, nil
+	}
+	return time.Parse(time.RFC3339Nano, raw)
+}
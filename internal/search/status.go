@@ -0,0 +1,46 @@
+package search
+
+import "fmt"
+
+// Status is the publication state of a gin.
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+	StatusArchived  Status = "archived"
+)
+
+// AllStatuses lists every publication state, for administrative listings.
+var AllStatuses = []Status{StatusDraft, StatusPublished, StatusArchived}
+
+// statusTransitions lists the states each status may move to. Archived is terminal.
+var statusTransitions = map[Status][]Status{
+	StatusDraft:     {StatusPublished, StatusArchived},
+	StatusPublished: {StatusArchived},
+}
+
+// ParseStatus validates a client-supplied publication status.
+func ParseStatus(value string) (Status, error) {
+	status := Status(value)
+	switch status {
+	case StatusDraft, StatusPublished, StatusArchived:
+		return status, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported status %q", ErrInvalidFilter, value)
+	}
+}
+
+// checkTransition reports whether a gin may move from one status to another. Keeping the
+// current status is always allowed.
+func checkTransition(from, to Status) error {
+	if from == to {
+		return nil
+	}
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: cannot change status from %s to %s", ErrInvalidStatusTransition, from, to)
+}
@@ -0,0 +1,47 @@
+package search
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		allowed  bool
+	}{
+		{from: StatusDraft, to: StatusDraft, allowed: true},
+		{from: StatusDraft, to: StatusPublished, allowed: true},
+		{from: StatusDraft, to: StatusArchived, allowed: true},
+		{from: StatusPublished, to: StatusPublished, allowed: true},
+		{from: StatusPublished, to: StatusArchived, allowed: true},
+		{from: StatusPublished, to: StatusDraft},
+		{from: StatusArchived, to: StatusArchived, allowed: true},
+		{from: StatusArchived, to: StatusDraft},
+		{from: StatusArchived, to: StatusPublished},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := checkTransition(tt.from, tt.to)
+			if tt.allowed && err != nil {
+				t.Fatalf("checkTransition returned error: %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrInvalidStatusTransition) {
+				t.Fatalf("error = %v, want ErrInvalidStatusTransition", err)
+			}
+		})
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	for _, status := range AllStatuses {
+		if got, err := ParseStatus(string(status)); err != nil || got != status {
+			t.Errorf("ParseStatus(%q) = %q, %v", status, got, err)
+		}
+	}
+
+	if _, err := ParseStatus("deleted"); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("ParseStatus(deleted) error = %v, want ErrInvalidFilter", err)
+	}
+}
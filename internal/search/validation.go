@@ -0,0 +1,231 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const maxVarcharLength = 255
+
+// GinInput carries the writable attributes of a gin for create and full-replace updates.
+type GinInput struct {
+	Name         string     `json:"name"`
+	DistilleryID *uuid.UUID `json:"distillery_id"`
+	Country      string     `json:"country"`
+	Region       string     `json:"region"`
+	ABV          *float64   `json:"abv"`
+	Botanicals   []string   `json:"botanicals"`
+	FlavorTags   []string   `json:"flavor_tags"`
+	Description  string     `json:"description"`
+	TastingNotes string     `json:"tasting_notes"`
+	ImageURL     string     `json:"image_url"`
+	// Status is the requested publication status. When empty, the current status is kept
+	// (draft for new gins).
+	Status Status `json:"status"`
+}
+
+// GinPatch carries a partial update; nil fields are left unchanged. Nullable attributes
+// use Nullable so that an explicit JSON null clears them.
+type GinPatch struct {
+	Name         *string             `json:"name"`
+	DistilleryID Nullable[uuid.UUID] `json:"distillery_id"`
+	Country      *string             `json:"country"`
+	Region       *string             `json:"region"`
+	ABV          Nullable[float64]   `json:"abv"`
+	Botanicals   *[]string           `json:"botanicals"`
+	FlavorTags   *[]string           `json:"flavor_tags"`
+	Description  *string             `json:"description"`
+	TastingNotes *string             `json:"tasting_notes"`
+	ImageURL     *string             `json:"image_url"`
+	Status       *Status             `json:"status"`
+}
+
+// Nullable is a patch field that tells an absent attribute, which is left unchanged, from
+// an explicit null, which clears it.
+type Nullable[T any] struct {
+	// Set reports whether the attribute was present in the request.
+	Set bool
+	// Value is the new value, or nil when the attribute is cleared.
+	Value *T
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It is only called for attributes present in
+// the request, including those set to null.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+func (in GinInput) apply(g *Gin) {
+	g.Name = strings.TrimSpace(in.Name)
+	g.DistilleryPublicID = in.DistilleryID
+	g.Country = strings.TrimSpace(in.Country)
+	g.Region = strings.TrimSpace(in.Region)
+	g.ABV = roundABV(in.ABV)
+	g.Botanicals = normalizeBotanicals(in.Botanicals)
+	g.FlavorTags = flavorTagsFromCodes(in.FlavorTags)
+	g.Description = strings.TrimSpace(in.Description)
+	g.TastingNotes = strings.TrimSpace(in.TastingNotes)
+	g.ImageURL = strings.TrimSpace(in.ImageURL)
+	if in.Status != "" {
+		g.Status = Status(strings.ToLower(strings.TrimSpace(string(in.Status))))
+	}
+}
+
+func (p GinPatch) apply(g *Gin) {
+	if p.Name != nil {
+		g.Name = strings.TrimSpace(*p.Name)
+	}
+	if p.DistilleryID.Set {
+		g.DistilleryPublicID = p.DistilleryID.Value
+	}
+	if p.Country != nil {
+		g.Country = strings.TrimSpace(*p.Country)
+	}
+	if p.Region != nil {
+		g.Region = strings.TrimSpace(*p.Region)
+	}
+	if p.ABV.Set {
+		g.ABV = roundABV(p.ABV.Value)
+	}
+	if p.Botanicals != nil {
+		g.Botanicals = normalizeBotanicals(*p.Botanicals)
+	}
+	if p.FlavorTags != nil {
+		g.FlavorTags = flavorTagsFromCodes(*p.FlavorTags)
+	}
+	if p.Description != nil {
+		g.Description = strings.TrimSpace(*p.Description)
+	}
+	if p.TastingNotes != nil {
+		g.TastingNotes = strings.TrimSpace(*p.TastingNotes)
+	}
+	if p.ImageURL != nil {
+		g.ImageURL = strings.TrimSpace(*p.ImageURL)
+	}
+	if p.Status != nil {
+		g.Status = Status(strings.ToLower(strings.TrimSpace(string(*p.Status))))
+	}
+}
+
+// validateGin checks a normalized gin against the column constraints of the gin table.
+func validateGin(g *Gin) error {
+	var problems []string
+
+	if g.Name == "" {
+		problems = append(problems, "name is required")
+	} else if utf8.RuneCountInString(g.Name) > maxVarcharLength {
+		problems = append(problems, fmt.Sprintf("name must be at most %d characters", maxVarcharLength))
+	}
+
+	if g.Country == "" {
+		problems = append(problems, "country is required")
+	} else if utf8.RuneCountInString(g.Country) > maxVarcharLength {
+		problems = append(problems, fmt.Sprintf("country must be at most %d characters", maxVarcharLength))
+	}
+
+	if utf8.RuneCountInString(g.Region) > maxVarcharLength {
+		problems = append(problems, fmt.Sprintf("region must be at most %d characters", maxVarcharLength))
+	}
+
+	if g.ABV != nil && (*g.ABV < 0 || *g.ABV > 100) {
+		problems = append(problems, "abv must be between 0 and 100")
+	}
+
+	if g.ImageURL != "" {
+		if parsed, err := url.Parse(g.ImageURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			problems = append(problems, "image_url must be an absolute http or https URL")
+		}
+	}
+
+	switch g.Status {
+	case StatusDraft, StatusPublished, StatusArchived:
+	default:
+		problems = append(problems, "status must be one of draft, published, archived")
+	}
+
+	for _, botanical := range g.Botanicals {
+		if utf8.RuneCountInString(botanical) > maxVarcharLength {
+			problems = append(problems, fmt.Sprintf("botanicals must be at most %d characters each", maxVarcharLength))
+			break
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidGin, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// normalizeBotanicals trims entries, collapses inner whitespace, drops blanks, and removes
+// case-insensitive duplicates while preserving the order in which botanicals were first listed.
+// This mirrors how the botanicals master table keys names.
+func normalizeBotanicals(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	results := make([]string, 0, len(values))
+	for _, value := range values {
+		trimmed := strings.Join(strings.Fields(value), " ")
+		if trimmed == "" {
+			continue
+		}
+		key := strings.ToLower(trimmed)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		results = append(results, trimmed)
+	}
+	return results
+}
+
+// normalizeFlavorTagCodes lowercases and trims codes, dropping blanks and duplicates.
+func normalizeFlavorTagCodes(codes []string) []string {
+	seen := make(map[string]struct{}, len(codes))
+	results := make([]string, 0, len(codes))
+	for _, code := range codes {
+		normalized := strings.ToLower(strings.TrimSpace(code))
+		if normalized == "" {
+			continue
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		results = append(results, normalized)
+	}
+	return results
+}
+
+func flavorTagsFromCodes(codes []string) FlavorTags {
+	normalized := normalizeFlavorTagCodes(codes)
+	tags := make(FlavorTags, len(normalized))
+	for i, code := range normalized {
+		tags[i] = FlavorTag{Code: code}
+	}
+	return tags
+}
+
+// roundABV rounds to the single decimal place stored by the NUMERIC(4,1) abv column.
+func roundABV(abv *float64) *float64 {
+	if abv == nil {
+		return nil
+	}
+	rounded := math.Round(*abv*10) / 10
+	return &rounded
+}
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGinPatchClearsNullableAttributes(t *testing.T) {
+	distilleryID := uuid.New()
+	abv := 43.0
+	otherID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		wantDistillery *uuid.UUID
+		wantABV        *float64
+	}{
+		{name: "absent attributes are kept", body: `{"name": "Roku"}`, wantDistillery: &distilleryID, wantABV: &abv},
+		{name: "null distillery is cleared", body: `{"distillery_id": null}`, wantABV: &abv},
+		{name: "null abv is cleared", body: `{"abv": null}`, wantDistillery: &distilleryID},
+		{name: "values replace", body: `{"distillery_id": "` + otherID.String() + `", "abv": 41.64}`, wantDistillery: &otherID, wantABV: func() *float64 { v := 41.6; return &v }()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch GinPatch
+			if err := json.Unmarshal([]byte(tt.body), &patch); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			current := distilleryID
+			currentABV := abv
+			gin := &Gin{Name: "Roku", Country: "Japan", DistilleryPublicID: &current, ABV: &currentABV, Status: StatusDraft}
+			patch.apply(gin)
+
+			switch {
+			case tt.wantDistillery == nil && gin.DistilleryPublicID != nil:
+				t.Errorf("distillery_id = %s, want cleared", gin.DistilleryPublicID)
+			case tt.wantDistillery != nil && (gin.DistilleryPublicID == nil || *gin.DistilleryPublicID != *tt.wantDistillery):
+				t.Errorf("distillery_id = %v, want %s", gin.DistilleryPublicID, tt.wantDistillery)
+			}
+			switch {
+			case tt.wantABV == nil && gin.ABV != nil:
+				t.Errorf("abv = %v, want cleared", *gin.ABV)
+			case tt.wantABV != nil && (gin.ABV == nil || *gin.ABV != *tt.wantABV):
+				t.Errorf("abv = %v, want %v", gin.ABV, *tt.wantABV)
+			}
+		})
+	}
+}
+
+func TestGinPatchRejectsMalformedNullable(t *testing.T) {
+	var patch GinPatch
+	if err := json.Unmarshal([]byte(`{"abv": "strong"}`), &patch); err == nil {
+		t.Fatal("unmarshal accepted a string abv")
+	}
+}
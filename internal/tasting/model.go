@@ -0,0 +1,109 @@
+package tasting
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the moderation state of a tasting log.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// AllStatuses lists every tasting status in lifecycle order.
+var AllStatuses = []Status{StatusPending, StatusApproved, StatusRejected}
+
+// ParseStatus converts a query value into a Status.
+func ParseStatus(value string) (Status, error) {
+	for _, status := range AllStatuses {
+		if string(status) == value {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown status %q", ErrInvalidTasting, value)
+}
+
+// Tasting is one user's record of tasting a gin. Read-only gin fields are loaded by the
+// repository in the same query as the tasting row.
+type Tasting struct {
+	ID          uint      `json:"-" gorm:"column:id;primaryKey"`
+	PublicID    uuid.UUID `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	UserID      string    `json:"user_id" gorm:"column:user_id;type:varchar(255);not null"`
+	GinID       uint      `json:"-" gorm:"column:gin_id;not null"`
+	GinPublicID uuid.UUID `json:"gin_id" gorm:"column:gin_public_id;type:uuid;->"`
+	GinName     string    `json:"gin_name" gorm:"column:gin_name;->"`
+	Rating      int       `json:"rating" gorm:"column:rating;type:smallint;not null"`
+	Memo        string    `json:"memo" gorm:"column:memo;type:text;not null"`
+	TastedOn    Date      `json:"tasted_on" gorm:"column:tasted_on;type:date;not null"`
+	Status      Status    `json:"status" gorm:"column:status;type:varchar(16);not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// TableName specifies the PostgreSQL table name for tasting logs.
+func (Tasting) TableName() string {
+	return "tasting_logs"
+}
+
+// dateLayout is the ISO 8601 calendar date format used in JSON.
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without a time of day, encoded as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of t in t's location.
+func NewDate(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(dateLayout))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return fmt.Errorf("date must use the YYYY-MM-DD format: %w", err)
+	}
+	*d = Date{parsed}
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (d Date) Value() (driver.Value, error) {
+	return d.Format(dateLayout), nil
+}
+
+// Scan implements sql.Scanner.
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		*d = NewDate(v)
+	case string:
+		parsed, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return err
+		}
+		*d = Date{parsed}
+	default:
+		return fmt.Errorf("unsupported date value %T", value)
+	}
+	return nil
+}
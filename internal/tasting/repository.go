@@ -0,0 +1,133 @@
+package tasting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository defines access methods to tasting log storage.
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Tasting, int64, error)
+	GetByID(ctx context.Context, userID string, id uuid.UUID) (*Tasting, error)
+	Create(ctx context.Context, tasting *Tasting) error
+	Update(ctx context.Context, tasting *Tasting) error
+}
+
+// ListFilter represents filtering and pagination options for listing a user's tastings.
+type ListFilter struct {
+	UserID   string
+	Statuses []Status
+	Limit    int
+	Offset   int
+}
+
+// Columns selects tasting rows together with the public identifier and name of the gin
+// they record.
+const Columns = `tasting_logs.*,
+(SELECT g.public_id FROM gin AS g WHERE g.id = tasting_logs.gin_id) AS gin_public_id,
+COALESCE((SELECT g.name FROM gin AS g WHERE g.id = tasting_logs.gin_id), '') AS gin_name`
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+// NewRepository constructs a Repository backed by GORM.
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) List(ctx context.Context, filter ListFilter) ([]Tasting, int64, error) {
+	var tastings []Tasting
+	var total int64
+
+	if err := applyListFilter(r.db.WithContext(ctx).Model(&Tasting{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tx := applyListFilter(r.db.WithContext(ctx).Model(&Tasting{}), filter).Select(Columns)
+
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		tx = tx.Offset(filter.Offset)
+	}
+
+	if err := tx.Order("tasted_on DESC, id DESC").Find(&tastings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return tastings, total, nil
+}
+
+func applyListFilter(tx *gorm.DB, filter ListFilter) *gorm.DB {
+	tx = tx.Where("user_id = ?", filter.UserID)
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		tx = tx.Where("status IN ?", statuses)
+	}
+
+	return tx
+}
+
+func (r *gormRepository) GetByID(ctx context.Context, userID string, id uuid.UUID) (*Tasting, error) {
+	var tasting Tasting
+
+	err := r.db.WithContext(ctx).
+		Select(Columns).
+		Where("public_id = ? AND user_id = ?", id, userID).
+		Take(&tasting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &tasting, nil
+}
+
+func (r *gormRepository) Create(ctx context.Context, tasting *Tasting) error {
+	var gin struct {
+		ID   uint
+		Name string
+	}
+	err := r.db.WithContext(ctx).Table("gin").
+		Select("id, name").
+		Where("public_id = ? AND status = ? AND deleted_at IS NULL", tasting.GinPublicID, "published").
+		Take(&gin).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: unknown gin_id", ErrInvalidTasting)
+		}
+		return err
+	}
+
+	tasting.GinID = gin.ID
+	tasting.GinName = gin.Name
+	return r.db.WithContext(ctx).Create(tasting).Error
+}
+
+func (r *gormRepository) Update(ctx context.Context, tasting *Tasting) error {
+	result := r.db.WithContext(ctx).
+		Model(tasting).
+		Where("user_id = ?", tasting.UserID).
+		Select("rating", "memo", "tasted_on", "status", "updated_at").
+		Updates(tasting)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package tasting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultListLimit is the page size used when a listing does not specify a limit.
+	DefaultListLimit = 20
+	// MaxListLimit bounds the page size of tasting listings.
+	MaxListLimit = 100
+)
+
+var (
+	// ErrRepositoryNotConfigured indicates that the service was constructed without a backing repository.
+	ErrRepositoryNotConfigured = errors.New("tasting repository not configured")
+	// ErrInvalidPagination is returned when the requested pagination parameters are out of range.
+	ErrInvalidPagination = errors.New("invalid pagination parameters")
+	// ErrNotFound is returned when the requested tasting does not exist or belongs to another user.
+	ErrNotFound = errors.New("tasting not found")
+	// ErrInvalidTasting is returned when a create or update request fails validation.
+	ErrInvalidTasting = errors.New("invalid tasting")
+)
+
+// Service manages users' tasting logs. Every method is scoped to a single user, identified
+// by the subject of their identity token.
+type Service struct {
+	repo Repository
+}
+
+// NewService constructs a new Service using the provided repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// List returns the user's tastings matching the filter, most recently tasted first, along
+// with the total number of matches.
+func (s *Service) List(ctx context.Context, userID string, filter ListFilter) ([]Tasting, int64, error) {
+	if s.repo == nil {
+		return nil, 0, ErrRepositoryNotConfigured
+	}
+
+	if filter.Limit < 0 || filter.Limit > MaxListLimit || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit must be between 0 and %d and offset non-negative", ErrInvalidPagination, MaxListLimit)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultListLimit
+	}
+
+	filter.UserID = userID
+	return s.repo.List(ctx, filter)
+}
+
+// Create validates the input and records a new tasting of a published gin. New tastings
+// start out pending.
+func (s *Service) Create(ctx context.Context, userID string, input Input) (*Tasting, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	tasting := &Tasting{PublicID: uuid.New(), UserID: userID, Status: StatusPending}
+	input.apply(tasting)
+	if err := validate(tasting); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, tasting); err != nil {
+		return nil, err
+	}
+	return tasting, nil
+}
+
+// Update applies a partial update to one of the user's tastings. Edited tastings go back
+// to pending so that moderators review the new content.
+func (s *Service) Update(ctx context.Context, userID string, id uuid.UUID, patch Patch) (*Tasting, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	if patch.empty() {
+		return nil, fmt.Errorf("%w: at least one of rating, memo or tasted_on is required", ErrInvalidTasting)
+	}
+
+	tasting, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patch.apply(tasting)
+	tasting.Status = StatusPending
+	if err := validate(tasting); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, tasting); err != nil {
+		return nil, err
+	}
+	return tasting, nil
+}
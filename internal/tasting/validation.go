@@ -0,0 +1,84 @@
+package tasting
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	minRating     = 1
+	maxRating     = 5
+	maxMemoLength = 1000
+)
+
+// Input carries the attributes of a new tasting log.
+type Input struct {
+	GinID    uuid.UUID `json:"gin_id"`
+	Rating   int       `json:"rating"`
+	Memo     string    `json:"memo"`
+	TastedOn *Date     `json:"tasted_on"`
+}
+
+// Patch carries a partial update; nil fields are left unchanged.
+type Patch struct {
+	Rating   *int    `json:"rating"`
+	Memo     *string `json:"memo"`
+	TastedOn *Date   `json:"tasted_on"`
+}
+
+func (in Input) apply(t *Tasting) {
+	t.GinPublicID = in.GinID
+	t.Rating = in.Rating
+	t.Memo = strings.TrimSpace(in.Memo)
+	if in.TastedOn != nil {
+		t.TastedOn = *in.TastedOn
+	}
+}
+
+func (p Patch) empty() bool {
+	return p.Rating == nil && p.Memo == nil && p.TastedOn == nil
+}
+
+func (p Patch) apply(t *Tasting) {
+	if p.Rating != nil {
+		t.Rating = *p.Rating
+	}
+	if p.Memo != nil {
+		t.Memo = strings.TrimSpace(*p.Memo)
+	}
+	if p.TastedOn != nil {
+		t.TastedOn = *p.TastedOn
+	}
+}
+
+func validate(t *Tasting) error {
+	var problems []string
+
+	if t.GinPublicID == uuid.Nil {
+		problems = append(problems, "gin_id is required")
+	}
+
+	if t.Rating < minRating || t.Rating > maxRating {
+		problems = append(problems, fmt.Sprintf("rating must be between %d and %d", minRating, maxRating))
+	}
+
+	if utf8.RuneCountInString(t.Memo) > maxMemoLength {
+		problems = append(problems, fmt.Sprintf("memo must be at most %d characters", maxMemoLength))
+	}
+
+	// Allow a day of slack so that users ahead of UTC can log a tasting from their today.
+	if t.TastedOn.IsZero() {
+		problems = append(problems, "tasted_on is required")
+	} else if t.TastedOn.After(time.Now().UTC().AddDate(0, 0, 1)) {
+		problems = append(problems, "tasted_on must not be in the future")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidTasting, strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role is a user's access level, synced from the identity provider's roles claim.
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+	// RoleGuest is an authenticated user who holds neither of the other roles.
+	RoleGuest Role = "guest"
+)
+
+// User is a person known to the identity provider. Rows are created the first time the
+// person makes an authenticated request; other tables, such as tasting_logs, refer to users
+// by AuthSubject.
+type User struct {
+	ID          uint      `json:"-" gorm:"column:id;primaryKey"`
+	PublicID    uuid.UUID `json:"id" gorm:"column:public_id;type:uuid;default:gen_random_uuid()"`
+	AuthSubject string    `json:"auth_subject" gorm:"column:auth_subject;type:varchar(255);not null"`
+	Email       string    `json:"email" gorm:"column:email;type:varchar(320);not null"`
+	DisplayName string    `json:"display_name" gorm:"column:display_name;type:varchar(255);not null"`
+	Role        Role      `json:"role" gorm:"column:role;type:varchar(16);not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// TableName specifies the PostgreSQL table name for users.
+func (User) TableName() string {
+	return "users"
+}
+
+// Identity is what a verified token says about its subject.
+type Identity struct {
+	Subject     string
+	Email       string
+	DisplayName string
+	Roles       []string
+}
+
+// role maps the identity's roles onto the highest matching Role.
+func (i Identity) role() Role {
+	role := RoleGuest
+	for _, candidate := range i.Roles {
+		switch Role(candidate) {
+		case RoleAdmin:
+			return RoleAdmin
+		case RoleMember:
+			role = RoleMember
+		}
+	}
+	return role
+}
@@ -0,0 +1,79 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines access methods to user storage.
+type Repository interface {
+	GetBySubject(ctx context.Context, subject string) (*User, error)
+	Upsert(ctx context.Context, user *User) error
+}
+
+type gormRepository struct {
+	db *gorm.DB
+}
+
+// NewRepository constructs a Repository backed by GORM.
+func NewRepository(db *gorm.DB) Repository {
+	return &gormRepository{db: db}
+}
+
+func (r *gormRepository) GetBySubject(ctx context.Context, subject string) (*User, error) {
+	var user User
+	err := r.db.WithContext(ctx).Where("auth_subject = ?", subject).Take(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// upsertQuery inserts a user or updates the existing row with the same subject in one
+// statement, so parallel first requests cannot create duplicates or fail on the unique
+// index. Blank claims keep the stored value, since access tokens do not always carry them.
+// The update only runs when a claim changed, so a concurrent request that already applied
+// the same claims leaves the row untouched and it is returned as is.
+const upsertQuery = `
+WITH upserted AS (
+    INSERT INTO users (auth_subject, email, display_name, role)
+    VALUES (@subject, @email, @display_name, @role)
+    ON CONFLICT (auth_subject) DO UPDATE SET
+        email = COALESCE(NULLIF(EXCLUDED.email, ''), users.email),
+        display_name = COALESCE(NULLIF(EXCLUDED.display_name, ''), users.display_name),
+        role = EXCLUDED.role
+    WHERE (users.email, users.display_name, users.role) IS DISTINCT FROM (
+        COALESCE(NULLIF(EXCLUDED.email, ''), users.email),
+        COALESCE(NULLIF(EXCLUDED.display_name, ''), users.display_name),
+        EXCLUDED.role
+    )
+    RETURNING *
+)
+SELECT * FROM upserted
+UNION ALL
+SELECT * FROM users WHERE auth_subject = @subject AND NOT EXISTS (SELECT 1 FROM upserted)`
+
+func (r *gormRepository) Upsert(ctx context.Context, user *User) error {
+	db := r.db.WithContext(ctx)
+	result := db.Raw(upsertQuery, map[string]interface{}{
+		"subject":      user.AuthSubject,
+		"email":        user.Email,
+		"display_name": user.DisplayName,
+		"role":         user.Role,
+	}).Scan(user)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// The row was created by a concurrent request after this statement took its snapshot,
+	// with the same claims; read it now that it is visible.
+	return db.Where("auth_subject = ?", user.AuthSubject).Take(user).Error
+}
@@ -0,0 +1,94 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxSubjectLength     = 255
+	maxEmailLength       = 320
+	maxDisplayNameLength = 255
+)
+
+var (
+	// ErrRepositoryNotConfigured indicates that the service was constructed without a backing repository.
+	ErrRepositoryNotConfigured = errors.New("user repository not configured")
+	// ErrInvalidIdentity is returned when an identity has no subject.
+	ErrInvalidIdentity = errors.New("invalid identity")
+	// ErrNotFound is returned when no user has the requested subject.
+	ErrNotFound = errors.New("user not found")
+)
+
+// Service provisions users from verified identity claims.
+type Service struct {
+	repo Repository
+}
+
+// NewService constructs a new Service using the provided repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Provision returns the user for identity, creating the user on first sight and updating
+// their email, display name and role whenever the claims change. It is safe to call from
+// concurrent requests for the same new user, and on every request: a known user whose
+// claims are unchanged costs a single read and takes no locks.
+func (s *Service) Provision(ctx context.Context, identity Identity) (*User, error) {
+	if s.repo == nil {
+		return nil, ErrRepositoryNotConfigured
+	}
+
+	identity = normalize(identity)
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("%w: subject is required", ErrInvalidIdentity)
+	}
+	if utf8.RuneCountInString(identity.Subject) > maxSubjectLength {
+		return nil, fmt.Errorf("%w: subject must be at most %d characters", ErrInvalidIdentity, maxSubjectLength)
+	}
+
+	user := &User{
+		AuthSubject: identity.Subject,
+		Email:       identity.Email,
+		DisplayName: identity.DisplayName,
+		Role:        identity.role(),
+	}
+
+	stored, err := s.repo.GetBySubject(ctx, user.AuthSubject)
+	switch {
+	case err == nil && !claimsChanged(stored, user):
+		return stored, nil
+	case err != nil && !errors.Is(err, ErrNotFound):
+		return nil, err
+	}
+
+	if err := s.repo.Upsert(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// claimsChanged reports whether provisioning claims would update stored. Blank claims keep
+// the stored value, as in the repository's upsert.
+func claimsChanged(stored, claims *User) bool {
+	return (claims.Email != "" && claims.Email != stored.Email) ||
+		(claims.DisplayName != "" && claims.DisplayName != stored.DisplayName) ||
+		claims.Role != stored.Role
+}
+
+func normalize(identity Identity) Identity {
+	identity.Subject = strings.TrimSpace(identity.Subject)
+	identity.Email = truncate(strings.TrimSpace(identity.Email), maxEmailLength)
+	identity.DisplayName = truncate(strings.Join(strings.Fields(identity.DisplayName), " "), maxDisplayNameLength)
+	return identity
+}
+
+func truncate(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return string([]rune(value)[:limit])
+}
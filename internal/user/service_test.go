@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"testing"
+)
+
+// memoryRepository stores users by subject and counts upserts.
+type memoryRepository struct {
+	users   map[string]User
+	upserts int
+}
+
+func (m *memoryRepository) GetBySubject(_ context.Context, subject string) (*User, error) {
+	user, ok := m.users[subject]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+func (m *memoryRepository) Upsert(_ context.Context, user *User) error {
+	m.upserts++
+	if stored, ok := m.users[user.AuthSubject]; ok {
+		if user.Email == "" {
+			user.Email = stored.Email
+		}
+		if user.DisplayName == "" {
+			user.DisplayName = stored.DisplayName
+		}
+	}
+	m.users[user.AuthSubject] = *user
+	return nil
+}
+
+func TestProvisionOnlyWritesChangedClaims(t *testing.T) {
+	repo := &memoryRepository{users: map[string]User{}}
+	service := NewService(repo)
+
+	alice := Identity{Subject: "auth0|alice", Email: "alice@example.com", DisplayName: "Alice", Roles: []string{"member"}}
+	tests := []struct {
+		name        string
+		identity    Identity
+		wantUpserts int
+		wantRole    Role
+		wantEmail   string
+	}{
+		{name: "first request creates the user", identity: alice, wantUpserts: 1, wantRole: RoleMember, wantEmail: "alice@example.com"},
+		{name: "unchanged claims only read", identity: alice, wantUpserts: 1, wantRole: RoleMember, wantEmail: "alice@example.com"},
+		{name: "blank claims keep stored values", identity: Identity{Subject: "auth0|alice", Roles: []string{"member"}}, wantUpserts: 1, wantRole: RoleMember, wantEmail: "alice@example.com"},
+		{name: "role change is written", identity: Identity{Subject: "auth0|alice", Roles: []string{"admin"}}, wantUpserts: 2, wantRole: RoleAdmin, wantEmail: "alice@example.com"},
+		{name: "email change is written", identity: Identity{Subject: "auth0|alice", Email: "a@example.com", Roles: []string{"admin"}}, wantUpserts: 3, wantRole: RoleAdmin, wantEmail: "a@example.com"},
+	}
+
+	for _, tt := range tests {
+		user, err := service.Provision(context.Background(), tt.identity)
+		if err != nil {
+			t.Fatalf("%s: Provision returned error: %v", tt.name, err)
+		}
+		if repo.upserts != tt.wantUpserts {
+			t.Fatalf("%s: %d upserts, want %d", tt.name, repo.upserts, tt.wantUpserts)
+		}
+		if user.Role != tt.wantRole || user.Email != tt.wantEmail {
+			t.Fatalf("%s: user = %+v, want role %s and email %s", tt.name, user, tt.wantRole, tt.wantEmail)
+		}
+	}
+}
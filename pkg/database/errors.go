@@ -0,0 +1,31 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgconn"
+)
+
+const (
+	uniqueViolationCode = "23505"
+	// integrityConstraintClass is the SQLSTATE class shared by unique, foreign key, check and
+	// not-null violations.
+	integrityConstraintClass = "23"
+)
+
+// IsUniqueViolation reports whether err was caused by a PostgreSQL unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// ConstraintViolation returns the PostgreSQL error when err was caused by any integrity
+// constraint violation, such as a unique, foreign key or check constraint.
+func ConstraintViolation(err error) (*pgconn.PgError, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && strings.HasPrefix(pgErr.Code, integrityConstraintClass) {
+		return pgErr, true
+	}
+	return nil, false
+}
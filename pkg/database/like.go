@@ -0,0 +1,11 @@
+package database
+
+import "strings"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// EscapeLike escapes LIKE wildcards so user input is matched literally. Patterns built
+// from it must be compared with ESCAPE '\'.
+func EscapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -0,0 +1,17 @@
+package database
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	for value, want := range map[string]string{
+		"gin":        "gin",
+		"100%":       `100\%`,
+		"old_tom":    `old\_tom`,
+		`back\slash`: `back\\slash`,
+		`\%_`:        `\\\%\_`,
+	} {
+		if got := EscapeLike(value); got != want {
+			t.Errorf("EscapeLike(%q) = %q, want %q", value, got, want)
+		}
+	}
+}